@@ -2,6 +2,7 @@ package activity
 
 import (
 	"context"
+	"time"
 )
 
 // key defines a custom type for context keys to avoid collisions.
@@ -15,6 +16,9 @@ const (
 	UserID
 	UserType
 	UserIP
+	Locale
+	RequestStart
+	APIVersion
 )
 
 func WithTransactionID(ctx context.Context, trxID string) context.Context {
@@ -78,6 +82,41 @@ func GetUserIP(ctx context.Context) (string, bool) {
 	return userIP, ok
 }
 
+// WithLocale adds the preferred locale (e.g. "id", "en") to the context.
+// Usually set by middleware from the Accept-Language header.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, Locale, locale)
+}
+
+// GetLocale retrieves the preferred locale from the context.
+func GetLocale(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(Locale).(string)
+	return locale, ok
+}
+
+// WithRequestStart records when the request started being processed.
+// Usually set by middleware; used to report processing latency.
+func WithRequestStart(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, RequestStart, start)
+}
+
+// GetRequestStart retrieves the request start time from the context.
+func GetRequestStart(ctx context.Context) (time.Time, bool) {
+	start, ok := ctx.Value(RequestStart).(time.Time)
+	return start, ok
+}
+
+// WithAPIVersion adds the API version serving the request (e.g. "v2") to the context.
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, APIVersion, version)
+}
+
+// GetAPIVersion retrieves the API version from the context.
+func GetAPIVersion(ctx context.Context) (string, bool) {
+	version, ok := ctx.Value(APIVersion).(string)
+	return version, ok
+}
+
 func WithCustomFields(ctx context.Context, key string, value interface{}) context.Context {
 	return context.WithValue(ctx, key, value)
 }
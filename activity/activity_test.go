@@ -3,6 +3,7 @@ package activity
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -58,6 +59,28 @@ func TestActivityContext(t *testing.T) {
 		assert.Equal(t, userIP, got)
 	})
 
+	t.Run("Locale", func(t *testing.T) {
+		ctx = WithLocale(ctx, "id")
+		got, ok := GetLocale(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "id", got)
+	})
+
+	t.Run("RequestStart", func(t *testing.T) {
+		start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		ctx = WithRequestStart(ctx, start)
+		got, ok := GetRequestStart(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, start, got)
+	})
+
+	t.Run("APIVersion", func(t *testing.T) {
+		ctx = WithAPIVersion(ctx, "v2")
+		got, ok := GetAPIVersion(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "v2", got)
+	})
+
 	t.Run("WithCustomFields", func(t *testing.T) {
 		key := "custom-key"
 		val := "custom-value"
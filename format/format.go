@@ -7,6 +7,7 @@
 //   - String helpers: Title case, unique append
 //   - Number formatting: Currency
 //   - Bank formatting: Account number (specific format)
+//   - Masking: account numbers, phone numbers, secrets
 //   - Safe type-to-string conversion for logging, cache keys, filenames, etc.
 package format
 
@@ -143,6 +144,56 @@ func BRINorek(norek string) string {
 		norek[:4], norek[4:6], norek[6:12], norek[12:14], norek[14:])
 }
 
+// Mask replaces every character of s except the first keepStart and the last
+// keepEnd with '*'. If s is too short to keep anything hidden, it is fully masked.
+// Works on runes, so multi-byte characters are never split.
+//
+// Example:
+//
+//	Mask("secret-token", 2, 2) // "se********en"
+func Mask(s string, keepStart, keepEnd int) string {
+	r := []rune(s)
+	// Guard against negative inputs
+	keepStart = max(keepStart, 0)
+	keepEnd = max(keepEnd, 0)
+	// Nothing would be hidden → hide everything
+	if keepStart+keepEnd >= len(r) {
+		return strings.Repeat("*", len(r))
+	}
+	for i := keepStart; i < len(r)-keepEnd; i++ {
+		r[i] = '*'
+	}
+	return string(r)
+}
+
+// MaskAccount masks a bank account number for display and logs,
+// keeping only the last 4 digits. Hyphens and spaces are removed first.
+//
+// Example:
+//
+//	MaskAccount("1234-56-789012-34-5") // "***********2345"
+func MaskAccount(norek string) string {
+	// Clean input
+	norek = strings.ReplaceAll(norek, "-", "")
+	norek = strings.ReplaceAll(norek, " ", "")
+	return Mask(norek, 0, 4)
+}
+
+// MaskPhone masks a phone number for display and logs, keeping the
+// prefix (first 4 characters, e.g. "0812" or "+628") and the last 3 digits.
+// Hyphens and spaces are removed first.
+//
+// Example:
+//
+//	MaskPhone("0812-3456-7890")  // "0812*****890"
+//	MaskPhone("+6281234567890") // "+628*******890"
+func MaskPhone(phone string) string {
+	// Clean input
+	phone = strings.ReplaceAll(phone, "-", "")
+	phone = strings.ReplaceAll(phone, " ", "")
+	return Mask(phone, 4, 3)
+}
+
 // formatNumber is a generic number formatter used internally by Rupiah.
 // Formats num with given decimal places, decimal separator, and thousand separator.
 func formatNumber(num float64, decimals int, decSep, thouSep string) string {
@@ -61,6 +61,41 @@ func TestFormatBRINorek(t *testing.T) {
 	}
 }
 
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		start    int
+		end      int
+		expected string
+	}{
+		{"keep both ends", "secret-token", 2, 2, "se********en"},
+		{"keep end only", "123456", 0, 4, "**3456"},
+		{"too short", "abc", 2, 2, "***"},
+		{"negative keep", "abcd", -1, -1, "****"},
+		{"multi-byte", "rahasiaé", 1, 1, "r******é"},
+		{"empty", "", 1, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Mask(tt.input, tt.start, tt.end))
+		})
+	}
+}
+
+func TestMaskAccount(t *testing.T) {
+	assert.Equal(t, "***********2345", MaskAccount("1234-56-789012-34-5"))
+	assert.Equal(t, "****5678", MaskAccount("1234 5678"))
+	assert.Equal(t, "****", MaskAccount("1234"))
+}
+
+func TestMaskPhone(t *testing.T) {
+	assert.Equal(t, "0812*****890", MaskPhone("0812-3456-7890"))
+	assert.Equal(t, "+628*******890", MaskPhone("+6281234567890"))
+	assert.Equal(t, "******", MaskPhone("081234"))
+}
+
 func TestFormatRupiah(t *testing.T) {
 	tests := []struct {
 		input    float64
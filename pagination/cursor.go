@@ -0,0 +1,300 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// Query parameters read by cursor pagination.
+const (
+	CursorParam = "cursor" // opaque token from next_cursor / prev_cursor
+	LimitParam  = "limit"  // items per page
+)
+
+// CursorDirection is the direction a cursor pages in.
+type CursorDirection string
+
+// Cursor directions.
+const (
+	Forward  CursorDirection = "next" // rows after the cursor
+	Backward CursorDirection = "prev" // rows before the cursor
+)
+
+// ErrInvalidCursor is matched (errors.Is) by every *CursorError.
+var ErrInvalidCursor = errors.New("pagination: invalid cursor")
+
+// CursorError reports a cursor token that cannot be used.
+// It implements StatusCode() so response.FromError answers 400 Bad Request.
+type CursorError struct {
+	Reason string // e.g. "malformed token"
+	Err    error  // underlying cause, e.g. ErrCursorExpired; may be nil
+}
+
+// Error implements the error interface.
+func (e *CursorError) Error() string {
+	return "invalid cursor: " + e.Reason
+}
+
+// StatusCode returns 400 Bad Request.
+func (e *CursorError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Is makes errors.Is(err, ErrInvalidCursor) match.
+func (e *CursorError) Is(target error) bool {
+	return target == ErrInvalidCursor
+}
+
+// Unwrap returns the underlying cause.
+func (e *CursorError) Unwrap() error {
+	return e.Err
+}
+
+// Cursor represents cursor-based pagination metadata.
+// Its JSON shape mirrors Pagination: limit, navigation flags and next/prev.
+// response.CursorPaginated places it in the top-level "cursor" object.
+//
+// Example JSON response:
+//
+//	"cursor": {
+//	  "limit": 20,
+//	  "has_more": true,
+//	  "has_prev": true,
+//	  "next_cursor": "eyJkIjoibmV4dCIsImsiOlsxMjNdfQ",
+//	  "prev_cursor": "eyJkIjoicHJldiIsImsiOlsxMDRdfQ"
+//	}
+type Cursor struct {
+	Limit   int  `json:"limit"`    // Items per page
+	HasMore bool `json:"has_more"` // A next page exists
+	HasPrev bool `json:"has_prev"` // A previous page exists
+
+	// Navigation helpers, pass back as ?cursor=
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// CursorState is what a cursor token carries: the direction and the sort key
+// values of the row it points at. Values round-trip through JSON: integers
+// come back as int64, other numbers as float64 and times as RFC 3339 strings.
+type CursorState struct {
+	Direction CursorDirection `json:"d"`
+	Values    []any           `json:"k"`
+}
+
+// CursorCodec turns cursor state into opaque tokens and back.
+type CursorCodec interface {
+	Encode(state CursorState) (string, error)
+	Decode(token string) (CursorState, error)
+}
+
+// Base64Codec encodes cursor state as URL-safe base64 JSON.
+// Tokens are opaque but not tamper-proof.
+type Base64Codec struct{}
+
+// Encode implements CursorCodec.
+func (Base64Codec) Encode(state CursorState) (string, error) {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Decode implements CursorCodec.
+func (Base64Codec) Decode(token string) (CursorState, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return CursorState{}, &CursorError{Reason: "malformed token"}
+	}
+	return decodeState(b)
+}
+
+// decodeState parses the JSON form of a CursorState.
+func decodeState(b []byte) (CursorState, error) {
+	var state CursorState
+	dec := json.NewDecoder(bytes.NewReader(b))
+	// Keep integer keys exact instead of float64
+	dec.UseNumber()
+	if err := dec.Decode(&state); err != nil {
+		return CursorState{}, &CursorError{Reason: "malformed token"}
+	}
+	if state.Direction != Forward && state.Direction != Backward {
+		return CursorState{}, &CursorError{Reason: "unknown direction"}
+	}
+	if len(state.Values) == 0 {
+		return CursorState{}, &CursorError{Reason: "missing sort key"}
+	}
+
+	for i, v := range state.Values {
+		if n, ok := v.(json.Number); ok {
+			state.Values[i] = numberValue(n)
+		}
+	}
+	return state, nil
+}
+
+// numberValue converts a JSON number to int64 when integral, float64 otherwise.
+func numberValue(n json.Number) any {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// CursorQuery is a parsed cursor request.
+type CursorQuery struct {
+	Limit     int             // Items per page (sanitized like New)
+	Direction CursorDirection // Forward on the first page
+	Values    []any           // Sort key values to seek from; nil on the first page
+
+	codec CursorCodec // encodes the cursors of the resulting page
+}
+
+// ParseCursor parses the ?cursor= and ?limit= values of a request.
+// An empty cursor means the first page. The limit is sanitized like New.
+//
+// Example:
+//
+//	q, err := pagination.ParseCursor(c.Query("cursor"), c.Query("limit"))
+//	if err != nil {
+//	    return response.FromError(ctx, err) // 400
+//	}
+func ParseCursor(cursor, limitStr string) (CursorQuery, error) {
+	return ParseCursorWith(Base64Codec{}, cursor, limitStr)
+}
+
+// ParseCursorWith is like ParseCursor but decodes the token with codec,
+// which is also used for the cursors of the resulting page.
+func ParseCursorWith(codec CursorCodec, cursor, limitStr string) (CursorQuery, error) {
+	q := CursorQuery{
+		Limit:     parseLimit(limitStr),
+		Direction: Forward,
+		codec:     codec,
+	}
+	if cursor == "" {
+		return q, nil
+	}
+
+	state, err := codec.Decode(cursor)
+	if err != nil {
+		return q, err
+	}
+	q.Direction = state.Direction
+	q.Values = state.Values
+	return q, nil
+}
+
+// ParseCursorQuery reads the cursor and limit from URL query values.
+func ParseCursorQuery(values url.Values) (CursorQuery, error) {
+	return ParseCursor(values.Get(CursorParam), values.Get(LimitParam))
+}
+
+// FetchLimit is the number of rows to query: one more than Limit, so the
+// extra row tells whether another page exists.
+func (q CursorQuery) FetchLimit() int {
+	return q.Limit + 1
+}
+
+// IsFirstPage reports whether the request carried no cursor.
+func (q CursorQuery) IsFirstPage() bool {
+	return q.Values == nil
+}
+
+// CursorPage trims rows fetched with q (at most FetchLimit, in query order:
+// ascending for Forward, reversed for Backward) to one page in display order
+// and builds its Cursor. key returns the sort key values of a row, in the
+// same order as the ORDER BY columns.
+//
+// Example:
+//
+//	rows := fetchUsers(q.Values, q.Direction, q.FetchLimit())
+//	users, cursor, err := pagination.CursorPage(q, rows, func(u User) []any {
+//	    return []any{u.CreatedAt, u.ID}
+//	})
+func CursorPage[T any](q CursorQuery, rows []T, key func(T) []any) ([]T, Cursor, error) {
+	codec := q.codec
+	if codec == nil {
+		codec = Base64Codec{}
+	}
+	limit := q.Limit
+	if limit < MinLimit {
+		limit = DefaultLimit
+	}
+
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+
+	c := Cursor{Limit: limit}
+	if q.Direction == Backward {
+		// Rows were fetched walking backwards; restore display order
+		rows = slices.Clone(rows)
+		slices.Reverse(rows)
+		c.HasPrev = more
+		c.HasMore = true // we came from a later page
+	} else {
+		c.HasMore = more
+		c.HasPrev = !q.IsFirstPage()
+	}
+
+	// An empty page has no rows to take keys from: a first page has nowhere
+	// to go, a page reached with a cursor points back to where it came from
+	first, last := q.Values, q.Values
+	if len(rows) == 0 && q.IsFirstPage() {
+		return rows, Cursor{Limit: limit}, nil
+	}
+	if len(rows) > 0 {
+		first, last = key(rows[0]), key(rows[len(rows)-1])
+	}
+
+	var err error
+	if c.HasMore {
+		if c.NextCursor, err = codec.Encode(CursorState{Direction: Forward, Values: last}); err != nil {
+			return nil, Cursor{}, fmt.Errorf("pagination: encode cursor: %w", err)
+		}
+	}
+	if c.HasPrev {
+		if c.PrevCursor, err = codec.Encode(CursorState{Direction: Backward, Values: first}); err != nil {
+			return nil, Cursor{}, fmt.Errorf("pagination: encode cursor: %w", err)
+		}
+	}
+	return rows, c, nil
+}
+
+// Links generates RFC 5988 Link headers with FULL URL (scheme + host + path)
+// for the next and previous cursors, like Pagination.Links.
+func (c Cursor) Links(baseURL string) (map[string]string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	path := u.Path
+	if u.RawPath != "" {
+		path = u.RawPath
+	}
+	base := fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, path)
+
+	links := make(map[string]string)
+	q := u.Query()
+	q.Set(LimitParam, strconv.Itoa(c.Limit))
+
+	if c.PrevCursor != "" {
+		q.Set(CursorParam, c.PrevCursor)
+		links["prev"] = fmt.Sprintf(`<%s?%s>; rel="prev"`, base, q.Encode())
+	}
+	if c.NextCursor != "" {
+		q.Set(CursorParam, c.NextCursor)
+		links["next"] = fmt.Sprintf(`<%s?%s>; rel="next"`, base, q.Encode())
+	}
+	return links, nil
+}
@@ -0,0 +1,175 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cursorRow struct {
+	ID    int64
+	Score float64
+}
+
+func cursorKey(r cursorRow) []any { return []any{r.Score, r.ID} }
+
+// fetch simulates "ORDER BY id" keyset queries over ids 1..total.
+func fetch(q CursorQuery, total int64) []cursorRow {
+	var rows []cursorRow
+	if q.Direction == Backward {
+		for id := q.Values[1].(int64) - 1; id >= 1 && len(rows) < q.FetchLimit(); id-- {
+			rows = append(rows, cursorRow{ID: id, Score: 0.5})
+		}
+		return rows
+	}
+
+	start := int64(1)
+	if !q.IsFirstPage() {
+		start = q.Values[1].(int64) + 1
+	}
+	for id := start; id <= total && len(rows) < q.FetchLimit(); id++ {
+		rows = append(rows, cursorRow{ID: id, Score: 0.5})
+	}
+	return rows
+}
+
+func ids(rows []cursorRow) []int64 {
+	out := make([]int64, len(rows))
+	for i, r := range rows {
+		out[i] = r.ID
+	}
+	return out
+}
+
+func TestCursorPage_Walk(t *testing.T) {
+	// First page
+	q, err := ParseCursor("", "2")
+	require.NoError(t, err)
+	assert.True(t, q.IsFirstPage())
+	assert.Equal(t, 3, q.FetchLimit())
+
+	rows, c, err := CursorPage(q, fetch(q, 5), cursorKey)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, ids(rows))
+	assert.True(t, c.HasMore)
+	assert.False(t, c.HasPrev)
+	assert.Empty(t, c.PrevCursor)
+
+	// Second page
+	q, err = ParseCursor(c.NextCursor, "2")
+	require.NoError(t, err)
+	assert.Equal(t, Forward, q.Direction)
+	assert.Equal(t, []any{0.5, int64(2)}, q.Values)
+
+	rows, c, err = CursorPage(q, fetch(q, 5), cursorKey)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 4}, ids(rows))
+	assert.True(t, c.HasMore)
+	assert.True(t, c.HasPrev)
+
+	// Last page
+	last, _ := ParseCursor(c.NextCursor, "2")
+	rows, lastCursor, err := CursorPage(last, fetch(last, 5), cursorKey)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, ids(rows))
+	assert.False(t, lastCursor.HasMore)
+	assert.Empty(t, lastCursor.NextCursor)
+
+	// Back from the second page to the first
+	q, err = ParseCursor(c.PrevCursor, "2")
+	require.NoError(t, err)
+	assert.Equal(t, Backward, q.Direction)
+
+	rows, c, err = CursorPage(q, fetch(q, 5), cursorKey)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, ids(rows)) // display order restored
+	assert.True(t, c.HasMore)
+	assert.False(t, c.HasPrev)
+	assert.NotEmpty(t, c.NextCursor)
+}
+
+func TestCursorPage_Empty(t *testing.T) {
+	q, _ := ParseCursor("", "")
+	rows, c, err := CursorPage(q, []cursorRow{}, cursorKey)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.Equal(t, Cursor{Limit: DefaultLimit}, c)
+}
+
+func TestCursorPage_EmptyAfterCursor(t *testing.T) {
+	// The last rows were deleted between two requests: the page is empty
+	// but still links back to the rows before the cursor
+	token, err := Base64Codec{}.Encode(CursorState{Direction: Forward, Values: []any{0.5, int64(4)}})
+	require.NoError(t, err)
+	q, err := ParseCursor(token, "2")
+	require.NoError(t, err)
+
+	rows, c, err := CursorPage(q, fetch(q, 4), cursorKey)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.False(t, c.HasMore)
+	assert.Empty(t, c.NextCursor)
+	assert.True(t, c.HasPrev)
+	require.NotEmpty(t, c.PrevCursor)
+
+	q, err = ParseCursor(c.PrevCursor, "2")
+	require.NoError(t, err)
+	rows, c, err = CursorPage(q, fetch(q, 4), cursorKey)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, ids(rows))
+	assert.True(t, c.HasMore)
+	assert.True(t, c.HasPrev)
+}
+
+func TestParseCursor_Invalid(t *testing.T) {
+	encode := func(s string) string {
+		token, _ := Base64Codec{}.Encode(CursorState{Direction: CursorDirection(s), Values: []any{1}})
+		return token
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		reason string
+	}{
+		{"not base64", "%%%", "malformed token"},
+		{"not json", "bm90LWpzb24", "malformed token"},
+		{"bad direction", encode("sideways"), "unknown direction"},
+		{"no values", "eyJkIjoibmV4dCIsImsiOltdfQ", "missing sort key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCursor(tt.token, "10")
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidCursor))
+
+			var cursorErr *CursorError
+			require.ErrorAs(t, err, &cursorErr)
+			assert.Equal(t, tt.reason, cursorErr.Reason)
+			assert.Equal(t, 400, cursorErr.StatusCode())
+		})
+	}
+}
+
+func TestParseCursorQuery(t *testing.T) {
+	q, err := ParseCursorQuery(url.Values{"limit": {"500000"}})
+	require.NoError(t, err)
+	assert.Equal(t, MaxLimit, q.Limit)
+	assert.Equal(t, Forward, q.Direction)
+}
+
+func TestCursorLinks(t *testing.T) {
+	c := Cursor{Limit: 20, NextCursor: "abc", PrevCursor: "xyz"}
+
+	links, err := c.Links("https://api.example.com/users?status=active&cursor=old")
+	require.NoError(t, err)
+	assert.Equal(t, `<https://api.example.com/users?cursor=abc&limit=20&status=active>; rel="next"`, links["next"])
+	assert.Equal(t, `<https://api.example.com/users?cursor=xyz&limit=20&status=active>; rel="prev"`, links["prev"])
+
+	_, err = c.Links("://bad")
+	assert.Error(t, err)
+}
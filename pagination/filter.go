@@ -0,0 +1,339 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxInValues is used when Filter.MaxInValues is 0.
+const DefaultMaxInValues = 100
+
+// FilterOp is a filter operator, the optional second bracket of
+// filter[field][op] (eq when omitted).
+type FilterOp string
+
+// Supported filter operators.
+const (
+	OpEq      FilterOp = "eq"      // column = value
+	OpNe      FilterOp = "ne"      // column <> value
+	OpIn      FilterOp = "in"      // column IN (a, b, ...), comma-separated
+	OpGte     FilterOp = "gte"     // column >= value
+	OpLte     FilterOp = "lte"     // column <= value
+	OpLike    FilterOp = "like"    // column LIKE %value% (value taken literally)
+	OpBetween FilterOp = "between" // column BETWEEN a AND b, "a,b"
+	OpIsNull  FilterOp = "is_null" // column IS [NOT] NULL, "true" / "false"
+)
+
+// FilterType is the type filter values are parsed as.
+type FilterType int
+
+// Filter value types.
+const (
+	FilterString FilterType = iota // as-is
+	FilterInt                      // int64
+	FilterFloat                    // float64
+	FilterBool                     // strconv.ParseBool
+	FilterTime                     // RFC 3339 or YYYY-MM-DD, as time.Time
+)
+
+// String returns the type name used in error messages.
+func (t FilterType) String() string {
+	switch t {
+	case FilterInt:
+		return "integer"
+	case FilterFloat:
+		return "number"
+	case FilterBool:
+		return "boolean"
+	case FilterTime:
+		return "date-time"
+	default:
+		return "string"
+	}
+}
+
+// FilterField allow-lists one filterable API field.
+type FilterField struct {
+	Column string     // trusted SQL column
+	Type   FilterType // value type
+	Ops    []FilterOp // allowed operators; eq only when empty
+}
+
+// ErrInvalidFilter is matched (errors.Is) by every *FilterError.
+var ErrInvalidFilter = errors.New("pagination: invalid filter")
+
+// FilterIssue is one rejected filter parameter. Its fields mirror
+// response.FieldError, so response.ValidationFailed lists them as-is.
+type FilterIssue struct {
+	Field   string // query key, e.g. "filter[amount][gte]"
+	Rule    string // "unknown", "operator", "type" or "format"
+	Param   string // expected type or allowed operators, if any
+	Message string // human-readable, lowercase
+}
+
+// FilterError reports every invalid filter parameter of a request.
+// It implements StatusCode() (422); response.FromError lists the issues in "errors".
+type FilterError struct {
+	Issues []FilterIssue
+}
+
+// Error implements the error interface.
+func (e *FilterError) Error() string {
+	if len(e.Issues) == 1 {
+		return "invalid filter: " + e.Issues[0].Message
+	}
+	return fmt.Sprintf("invalid filter: %s (and %d more)", e.Issues[0].Message, len(e.Issues)-1)
+}
+
+// StatusCode returns 422 Unprocessable Entity.
+func (e *FilterError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// Is makes errors.Is(err, ErrInvalidFilter) match.
+func (e *FilterError) Is(target error) bool {
+	return target == ErrInvalidFilter
+}
+
+// FilterClause is a parameterised WHERE condition.
+type FilterClause struct {
+	Where string // conditions joined with AND, without "WHERE"; "" when no filter is set
+	Args  []any  // bind arguments of Where
+}
+
+// SQL returns the clause as "WHERE ...", or "" when no filter is set.
+func (c FilterClause) SQL() string {
+	if c.Where == "" {
+		return ""
+	}
+	return "WHERE " + c.Where
+}
+
+// Filter parses list filters of the form
+//
+//	filter[status]=active
+//	filter[amount][gte]=10000
+//	filter[created_at][between]=2026-01-01,2026-02-01
+//	filter[id][in]=1,2,3
+//	filter[deleted_at][is_null]=true
+//
+// against an allow-list of fields, types and operators.
+//
+// Example:
+//
+//	var orderFilter = pagination.Filter{Fields: map[string]pagination.FilterField{
+//	    "status":     {Column: "o.status"},
+//	    "amount":     {Column: "o.amount", Type: pagination.FilterInt, Ops: []pagination.FilterOp{pagination.OpGte, pagination.OpLte}},
+//	    "created_at": {Column: "o.created_at", Type: pagination.FilterTime, Ops: []pagination.FilterOp{pagination.OpBetween}},
+//	}}
+//
+//	f, err := orderFilter.Parse(r.URL.Query())
+//	if err != nil {
+//	    return response.FromError(ctx, err) // 422 with "errors"
+//	}
+//	rows, err := db.Query("SELECT * FROM orders o "+f.SQL(), f.Args...)
+type Filter struct {
+	Fields      map[string]FilterField // API field → definition
+	Dialect     Dialect                // placeholder style
+	ArgOffset   int                    // bind parameters already used by the query
+	MaxInValues int                    // maximum values of "in" (DefaultMaxInValues when 0)
+}
+
+// comparisonOps are the SQL operators of the single-value operators.
+var comparisonOps = map[FilterOp]string{OpEq: "=", OpNe: "<>", OpGte: ">=", OpLte: "<="}
+
+// filterKey matches filter[field] and filter[field][op].
+var filterKey = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// Parse turns the filter[...] parameters of query into a WHERE clause.
+// Other parameters are ignored. All invalid parameters are reported together
+// in a *FilterError.
+//
+// When combining with Keyset, set Keyset.ArgOffset to len(clause.Args).
+func (f Filter) Parse(query url.Values) (FilterClause, error) {
+	// Sorted keys give a stable SQL text (and prepared statement cache hits)
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if strings.HasPrefix(k, "filter[") {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	b := filterBuilder{filter: f, next: f.ArgOffset + 1}
+	for _, key := range keys {
+		m := filterKey.FindStringSubmatch(key)
+		if m == nil {
+			b.issue(key, "format", "", "malformed filter parameter, use filter[field] or filter[field][op]")
+			continue
+		}
+
+		name, op := m[1], FilterOp(m[2])
+		if op == "" {
+			op = OpEq
+		}
+		field, ok := f.Fields[name]
+		if !ok {
+			b.issue(key, "unknown", "", fmt.Sprintf("%s cannot be filtered", name))
+			continue
+		}
+		if !allowsOp(field, op) {
+			b.issue(key, "operator", joinOps(field), fmt.Sprintf("%s does not support %s", name, op))
+			continue
+		}
+
+		for _, raw := range query[key] {
+			b.add(key, field, op, raw)
+		}
+	}
+
+	if len(b.issues) > 0 {
+		return FilterClause{}, &FilterError{Issues: b.issues}
+	}
+	return FilterClause{Where: strings.Join(b.conds, " AND "), Args: b.args}, nil
+}
+
+// allowsOp reports whether field accepts op.
+func allowsOp(field FilterField, op FilterOp) bool {
+	if len(field.Ops) == 0 {
+		return op == OpEq
+	}
+	return slices.Contains(field.Ops, op)
+}
+
+// joinOps lists the operators of field for error messages.
+func joinOps(field FilterField) string {
+	if len(field.Ops) == 0 {
+		return string(OpEq)
+	}
+	ops := make([]string, len(field.Ops))
+	for i, op := range field.Ops {
+		ops[i] = string(op)
+	}
+	return strings.Join(ops, ",")
+}
+
+// filterBuilder accumulates conditions, arguments and issues.
+type filterBuilder struct {
+	filter Filter
+	next   int // next placeholder number
+	conds  []string
+	args   []any
+	issues []FilterIssue
+}
+
+// issue records an invalid parameter.
+func (b *filterBuilder) issue(key, rule, param, message string) {
+	b.issues = append(b.issues, FilterIssue{Field: key, Rule: rule, Param: param, Message: message})
+}
+
+// bind adds v as an argument and returns its placeholder.
+func (b *filterBuilder) bind(v any) string {
+	b.args = append(b.args, v)
+	p := b.filter.Dialect.placeholder(b.next)
+	b.next++
+	return p
+}
+
+// add parses raw for op and appends the condition.
+func (b *filterBuilder) add(key string, field FilterField, op FilterOp, raw string) {
+	col := field.Column
+
+	switch op {
+	case OpIsNull:
+		isNull, err := strconv.ParseBool(raw)
+		if err != nil {
+			b.issue(key, "type", FilterBool.String(), fmt.Sprintf("%s must be true or false", key))
+			return
+		}
+		if isNull {
+			b.conds = append(b.conds, col+" IS NULL")
+		} else {
+			b.conds = append(b.conds, col+" IS NOT NULL")
+		}
+
+	case OpLike:
+		// Match the value literally, anywhere in the column
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(raw)
+		b.conds = append(b.conds, col+" LIKE "+b.bind("%"+escaped+"%"))
+
+	case OpIn:
+		maxValues := b.filter.MaxInValues
+		if maxValues <= 0 {
+			maxValues = DefaultMaxInValues
+		}
+		parts := strings.Split(raw, ",")
+		if len(parts) > maxValues {
+			b.issue(key, "format", strconv.Itoa(maxValues), fmt.Sprintf("%s accepts at most %d values", key, maxValues))
+			return
+		}
+		values, ok := b.parseAll(key, field.Type, parts)
+		if !ok {
+			return
+		}
+		params := make([]string, len(values))
+		for i, v := range values {
+			params[i] = b.bind(v)
+		}
+		b.conds = append(b.conds, col+" IN ("+strings.Join(params, ", ")+")")
+
+	case OpBetween:
+		parts := strings.Split(raw, ",")
+		if len(parts) != 2 {
+			b.issue(key, "format", "", fmt.Sprintf("%s must be two comma-separated values", key))
+			return
+		}
+		values, ok := b.parseAll(key, field.Type, parts)
+		if !ok {
+			return
+		}
+		b.conds = append(b.conds, col+" BETWEEN "+b.bind(values[0])+" AND "+b.bind(values[1]))
+
+	default:
+		values, ok := b.parseAll(key, field.Type, []string{raw})
+		if !ok {
+			return
+		}
+		b.conds = append(b.conds, col+" "+comparisonOps[op]+" "+b.bind(values[0]))
+	}
+}
+
+// parseAll converts every part to t, recording one issue on failure.
+func (b *filterBuilder) parseAll(key string, t FilterType, parts []string) ([]any, bool) {
+	values := make([]any, len(parts))
+	for i, part := range parts {
+		v, err := parseFilterValue(t, strings.TrimSpace(part))
+		if err != nil {
+			b.issue(key, "type", t.String(), fmt.Sprintf("%s must be a valid %s", key, t))
+			return nil, false
+		}
+		values[i] = v
+	}
+	return values, true
+}
+
+// parseFilterValue converts s to the Go value of t.
+func parseFilterValue(t FilterType, s string) (any, error) {
+	switch t {
+	case FilterInt:
+		return strconv.ParseInt(s, 10, 64)
+	case FilterFloat:
+		return strconv.ParseFloat(s, 64)
+	case FilterBool:
+		return strconv.ParseBool(s)
+	case FilterTime:
+		if ts, err := time.Parse(time.RFC3339, s); err == nil {
+			return ts, nil
+		}
+		return time.Parse(time.DateOnly, s)
+	default:
+		return s, nil
+	}
+}
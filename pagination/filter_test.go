@@ -0,0 +1,134 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOrderFilter = Filter{Fields: map[string]FilterField{
+	"status":     {Column: "o.status", Ops: []FilterOp{OpEq, OpNe, OpIn}},
+	"amount":     {Column: "o.amount", Type: FilterInt, Ops: []FilterOp{OpGte, OpLte}},
+	"created_at": {Column: "o.created_at", Type: FilterTime, Ops: []FilterOp{OpBetween}},
+	"note":       {Column: "o.note", Ops: []FilterOp{OpLike}},
+	"deleted_at": {Column: "o.deleted_at", Type: FilterTime, Ops: []FilterOp{OpIsNull}},
+	"id":         {Column: "o.id", Type: FilterInt, Ops: []FilterOp{OpIn}},
+	"email":      {Column: "o.email"},
+}}
+
+func TestFilter_Parse(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query url.Values
+		where string
+		args  []any
+	}{
+		{"no filter", url.Values{"page": {"2"}}, "", nil},
+		{"eq implicit", url.Values{"filter[email]": {"budi@example.com"}}, "o.email = $1", []any{"budi@example.com"}},
+		{"eq explicit", url.Values{"filter[status][eq]": {"active"}}, "o.status = $1", []any{"active"}},
+		{"ne", url.Values{"filter[status][ne]": {"void"}}, "o.status <> $1", []any{"void"}},
+		{"in", url.Values{"filter[id][in]": {"1, 2,3"}}, "o.id IN ($1, $2, $3)", []any{int64(1), int64(2), int64(3)}},
+		{"range", url.Values{"filter[amount][gte]": {"10000"}, "filter[amount][lte]": {"50000"}},
+			"o.amount >= $1 AND o.amount <= $2", []any{int64(10000), int64(50000)}},
+		{"between dates", url.Values{"filter[created_at][between]": {"2026-01-01,2026-02-01T00:00:00Z"}},
+			"o.created_at BETWEEN $1 AND $2", []any{jan, feb}},
+		{"like escapes wildcards", url.Values{"filter[note][like]": {`50%_off\`}}, "o.note LIKE $1", []any{`%50\%\_off\\%`}},
+		{"is null", url.Values{"filter[deleted_at][is_null]": {"true"}}, "o.deleted_at IS NULL", nil},
+		{"is not null", url.Values{"filter[deleted_at][is_null]": {"false"}}, "o.deleted_at IS NOT NULL", nil},
+		{"repeated key", url.Values{"filter[status][ne]": {"void", "draft"}}, "o.status <> $1 AND o.status <> $2", []any{"void", "draft"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := testOrderFilter.Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.where, c.Where)
+			assert.Equal(t, tt.args, c.Args)
+		})
+	}
+}
+
+func TestFilter_ParseStableOrder(t *testing.T) {
+	query := url.Values{"filter[status]": {"active"}, "filter[amount][gte]": {"1"}, "filter[email]": {"a@b.c"}}
+
+	c, err := testOrderFilter.Parse(query)
+	require.NoError(t, err)
+	assert.Equal(t, "o.amount >= $1 AND o.email = $2 AND o.status = $3", c.Where)
+	assert.Equal(t, "WHERE o.amount >= $1 AND o.email = $2 AND o.status = $3", c.SQL())
+}
+
+func TestFilter_DialectAndOffset(t *testing.T) {
+	query := url.Values{"filter[id][in]": {"7,8"}}
+
+	f := testOrderFilter
+	f.ArgOffset = 2
+	c, err := f.Parse(query)
+	require.NoError(t, err)
+	assert.Equal(t, "o.id IN ($3, $4)", c.Where)
+
+	f.Dialect = MySQL
+	c, err = f.Parse(query)
+	require.NoError(t, err)
+	assert.Equal(t, "o.id IN (?, ?)", c.Where)
+	assert.Equal(t, []any{int64(7), int64(8)}, c.Args)
+}
+
+func TestFilter_Errors(t *testing.T) {
+	f := testOrderFilter
+	f.MaxInValues = 2
+
+	_, err := f.Parse(url.Values{
+		"filter[password]":            {"x"},
+		"filter[email][like]":         {"a"},
+		"filter[amount][gte]":         {"ten"},
+		"filter[created_at][between]": {"2026-01-01"},
+		"filter[id][in]":              {"1,2,3"},
+		"filter[status":               {"active"},
+		"filter[deleted_at][is_null]": {"yes"},
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+
+	var fe *FilterError
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, 422, fe.StatusCode())
+	assert.Equal(t, []FilterIssue{
+		{Field: "filter[amount][gte]", Rule: "type", Param: "integer", Message: "filter[amount][gte] must be a valid integer"},
+		{Field: "filter[created_at][between]", Rule: "format", Message: "filter[created_at][between] must be two comma-separated values"},
+		{Field: "filter[deleted_at][is_null]", Rule: "type", Param: "boolean", Message: "filter[deleted_at][is_null] must be true or false"},
+		{Field: "filter[email][like]", Rule: "operator", Param: "eq", Message: "email does not support like"},
+		{Field: "filter[id][in]", Rule: "format", Param: "2", Message: "filter[id][in] accepts at most 2 values"},
+		{Field: "filter[password]", Rule: "unknown", Message: "password cannot be filtered"},
+		{Field: "filter[status", Rule: "format", Message: "malformed filter parameter, use filter[field] or filter[field][op]"},
+	}, fe.Issues)
+	assert.Equal(t, "invalid filter: filter[amount][gte] must be a valid integer (and 6 more)", err.Error())
+}
+
+func TestFilter_InvalidTime(t *testing.T) {
+	_, err := testOrderFilter.Parse(url.Values{"filter[created_at][between]": {"2026-01-01,yesterday"}})
+
+	var fe *FilterError
+	require.True(t, errors.As(err, &fe))
+	require.Len(t, fe.Issues, 1)
+	assert.Equal(t, "date-time", fe.Issues[0].Param)
+	assert.Equal(t, "invalid filter: filter[created_at][between] must be a valid date-time", err.Error())
+}
+
+func TestFilter_ComposesWithKeyset(t *testing.T) {
+	f, err := testOrderFilter.Parse(url.Values{"filter[status]": {"active"}})
+	require.NoError(t, err)
+
+	ks := Keyset{Columns: []SortColumn{{Column: "o.id"}}, ArgOffset: len(f.Args)}
+	c, err := ks.Build(CursorQuery{Limit: 10, Direction: Forward, Values: []any{int64(42)}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "o.status = $1", f.Where)
+	assert.Equal(t, "o.id > $2", c.Where)
+}
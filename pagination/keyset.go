@@ -0,0 +1,280 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect selects the SQL flavor of generated clauses.
+type Dialect int
+
+// Supported SQL dialects.
+const (
+	PostgreSQL Dialect = iota // $1, $2 placeholders, NULLS FIRST/LAST
+	MySQL                     // ? placeholders, NULL ordering emulated with IS NULL
+)
+
+// placeholder returns the n-th (1-based) bind parameter.
+func (d Dialect) placeholder(n int) string {
+	if d == MySQL {
+		return "?"
+	}
+	return "$" + strconv.Itoa(n)
+}
+
+// NullsOrder places NULLs of a nullable sort column.
+type NullsOrder int
+
+// NULL orderings. NullsDefault declares the column NOT NULL.
+const (
+	NullsDefault NullsOrder = iota
+	NullsFirst
+	NullsLast
+)
+
+// SortColumn is one ORDER BY column of a keyset. Column is trusted SQL,
+// never user input (see Sorter for mapping API names to columns).
+type SortColumn struct {
+	Column string     // e.g. "created_at" or "u.id"
+	Desc   bool       // descending order
+	Nulls  NullsOrder // set for nullable columns
+}
+
+// Keyset builds seek-pagination clauses for a fixed ORDER BY. The last column
+// should be unique (usually the primary key) so the order is total.
+//
+// Example:
+//
+//	ks := pagination.Keyset{
+//	    Dialect: pagination.PostgreSQL,
+//	    Columns: []pagination.SortColumn{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+//	}
+//	c, err := ks.Build(q) // or Columns: sort, from Sorter.Parse
+//	rows, err := db.Query("SELECT * FROM users "+c.SQL(), c.Args...)
+type Keyset struct {
+	Dialect   Dialect
+	Columns   []SortColumn
+	ArgOffset int // bind parameters already used by the query ($n numbering starts after them)
+}
+
+// Clause is a generated WHERE / ORDER BY / LIMIT fragment.
+type Clause struct {
+	Where   string // seek predicate without "WHERE"; "" on the first page
+	OrderBy string // without "ORDER BY"
+	Limit   int    // CursorQuery.FetchLimit
+	Args    []any  // bind arguments of Where
+}
+
+// SQL returns the clause as "WHERE ... ORDER BY ... LIMIT n".
+func (c Clause) SQL() string {
+	var b strings.Builder
+	if c.Where != "" {
+		b.WriteString("WHERE ")
+		b.WriteString(c.Where)
+		b.WriteString(" ")
+	}
+	b.WriteString("ORDER BY ")
+	b.WriteString(c.OrderBy)
+	b.WriteString(" LIMIT ")
+	b.WriteString(strconv.Itoa(c.Limit))
+	return b.String()
+}
+
+// Build returns the clause that fetches the page of q: rows after
+// (or, for Backward, before) the cursor values, in traversal order,
+// limited to q.FetchLimit. Pass the rows to CursorPage.
+//
+// A cursor whose value count differs from the columns returns a *CursorError.
+func (k Keyset) Build(q CursorQuery) (Clause, error) {
+	if len(k.Columns) == 0 {
+		return Clause{}, fmt.Errorf("pagination: keyset without columns")
+	}
+
+	// Walking backwards reverses every column, NULL placement included
+	cols := make([]SortColumn, len(k.Columns))
+	for i, col := range k.Columns {
+		if q.Direction == Backward {
+			col.Desc = !col.Desc
+			switch col.Nulls {
+			case NullsFirst:
+				col.Nulls = NullsLast
+			case NullsLast:
+				col.Nulls = NullsFirst
+			}
+		}
+		cols[i] = col
+	}
+
+	c := Clause{OrderBy: k.Dialect.orderBy(cols), Limit: q.FetchLimit()}
+	if q.IsFirstPage() {
+		return c, nil
+	}
+	if len(q.Values) != len(cols) {
+		return Clause{}, &CursorError{Reason: "sort key mismatch"}
+	}
+
+	b := seekBuilder{dialect: k.Dialect, next: k.ArgOffset + 1}
+	if tupleComparable(cols) {
+		c.Where = b.tuple(cols, q.Values)
+	} else {
+		c.Where = b.expanded(cols, q.Values)
+	}
+	c.Args = b.args
+	return c, nil
+}
+
+// orderBy renders the ORDER BY list.
+func (d Dialect) orderBy(cols []SortColumn) string {
+	parts := make([]string, 0, len(cols))
+	for _, col := range cols {
+		dir := "ASC"
+		if col.Desc {
+			dir = "DESC"
+		}
+
+		switch {
+		case col.Nulls == NullsDefault:
+			parts = append(parts, col.Column+" "+dir)
+		case d == MySQL:
+			// MySQL sorts NULLs first ascending; order by "IS NULL" (0/1) instead
+			nullsDir := "ASC"
+			if col.Nulls == NullsFirst {
+				nullsDir = "DESC"
+			}
+			parts = append(parts, col.Column+" IS NULL "+nullsDir, col.Column+" "+dir)
+		default:
+			nulls := "NULLS LAST"
+			if col.Nulls == NullsFirst {
+				nulls = "NULLS FIRST"
+			}
+			parts = append(parts, col.Column+" "+dir+" "+nulls)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// tupleComparable reports whether a row-value comparison can be used:
+// every column shares one direction and none is nullable.
+func tupleComparable(cols []SortColumn) bool {
+	for _, col := range cols {
+		if col.Nulls != NullsDefault || col.Desc != cols[0].Desc {
+			return false
+		}
+	}
+	return true
+}
+
+// seekBuilder renders seek predicates and collects their arguments.
+type seekBuilder struct {
+	dialect Dialect
+	next    int            // next placeholder number
+	args    []any          // bind arguments in placeholder order
+	bound   map[int]string // PostgreSQL placeholder per column, reused
+}
+
+// bind returns the placeholder of the value of column i. PostgreSQL reuses
+// one numbered parameter per column; MySQL needs one argument per "?",
+// so predicates must be rendered in textual order.
+func (b *seekBuilder) bind(i int, v any) string {
+	if b.dialect != MySQL {
+		if p, ok := b.bound[i]; ok {
+			return p
+		}
+	}
+
+	p := b.dialect.placeholder(b.next)
+	b.next++
+	b.args = append(b.args, v)
+	if b.dialect != MySQL {
+		if b.bound == nil {
+			b.bound = make(map[int]string)
+		}
+		b.bound[i] = p
+	}
+	return p
+}
+
+// tuple renders "(a, b) > ($1, $2)".
+func (b *seekBuilder) tuple(cols []SortColumn, values []any) string {
+	op := ">"
+	if cols[0].Desc {
+		op = "<"
+	}
+	if len(cols) == 1 {
+		return cols[0].Column + " " + op + " " + b.bind(0, values[0])
+	}
+
+	names := make([]string, len(cols))
+	params := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.Column
+		params[i] = b.bind(i, values[i])
+	}
+	return "(" + strings.Join(names, ", ") + ") " + op + " (" + strings.Join(params, ", ") + ")"
+}
+
+// expanded renders "a > $1 OR (a = $1 AND b < $2) OR ...", handling mixed
+// directions and NULLs.
+func (b *seekBuilder) expanded(cols []SortColumn, values []any) string {
+	var terms []string
+	for i, col := range cols {
+		if !canFollow(col, values[i]) {
+			continue
+		}
+		conds := make([]string, 0, i+1)
+		for j := range i {
+			conds = append(conds, b.same(j, cols[j], values[j]))
+		}
+		conds = append(conds, b.after(i, col, values[i]))
+		terms = append(terms, joinAnd(conds))
+	}
+
+	switch len(terms) {
+	case 0:
+		// The cursor row is the last possible one
+		return "1 = 0"
+	case 1:
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// canFollow reports whether any value of col sorts after v.
+func canFollow(col SortColumn, v any) bool {
+	// Only NULLs follow a NULL when NULLs sort last
+	return !(v == nil && col.Nulls == NullsLast)
+}
+
+// after renders "column i sorts after v" in traversal order.
+func (b *seekBuilder) after(i int, col SortColumn, v any) string {
+	if v == nil && col.Nulls == NullsFirst {
+		return col.Column + " IS NOT NULL"
+	}
+
+	op := ">"
+	if col.Desc {
+		op = "<"
+	}
+	cond := col.Column + " " + op + " " + b.bind(i, v)
+	if col.Nulls == NullsLast {
+		cond = "(" + cond + " OR " + col.Column + " IS NULL)"
+	}
+	return cond
+}
+
+// same renders "column i sorts equal to v".
+func (b *seekBuilder) same(i int, col SortColumn, v any) string {
+	if v == nil {
+		return col.Column + " IS NULL"
+	}
+	return col.Column + " = " + b.bind(i, v)
+}
+
+// joinAnd joins conditions with AND, parenthesized when there is more than one.
+func joinAnd(conds []string) string {
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	return "(" + strings.Join(conds, " AND ") + ")"
+}
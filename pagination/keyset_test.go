@@ -0,0 +1,147 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seekQuery(direction CursorDirection, values ...any) CursorQuery {
+	return CursorQuery{Limit: 20, Direction: direction, Values: values}
+}
+
+func TestKeyset_Build(t *testing.T) {
+	byID := []SortColumn{{Column: "id"}}
+	newest := []SortColumn{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+	mixed := []SortColumn{{Column: "name"}, {Column: "id", Desc: true}}
+	nullable := []SortColumn{{Column: "deleted_at", Nulls: NullsLast}, {Column: "id"}}
+
+	tests := []struct {
+		name    string
+		keyset  Keyset
+		query   CursorQuery
+		where   string
+		orderBy string
+		args    []any
+	}{
+		{
+			name:    "first page",
+			keyset:  Keyset{Columns: newest},
+			query:   CursorQuery{Limit: 20, Direction: Forward},
+			orderBy: "created_at DESC, id DESC",
+		},
+		{
+			name:    "single column",
+			keyset:  Keyset{Columns: byID},
+			query:   seekQuery(Forward, int64(10)),
+			where:   "id > $1",
+			orderBy: "id ASC",
+			args:    []any{int64(10)},
+		},
+		{
+			name:    "row value descending",
+			keyset:  Keyset{Columns: newest},
+			query:   seekQuery(Forward, "2026-01-01T00:00:00Z", int64(7)),
+			where:   "(created_at, id) < ($1, $2)",
+			orderBy: "created_at DESC, id DESC",
+			args:    []any{"2026-01-01T00:00:00Z", int64(7)},
+		},
+		{
+			name:    "backward flips",
+			keyset:  Keyset{Columns: newest},
+			query:   seekQuery(Backward, "2026-01-01T00:00:00Z", int64(7)),
+			where:   "(created_at, id) > ($1, $2)",
+			orderBy: "created_at ASC, id ASC",
+			args:    []any{"2026-01-01T00:00:00Z", int64(7)},
+		},
+		{
+			name:    "arg offset",
+			keyset:  Keyset{Columns: byID, ArgOffset: 2},
+			query:   seekQuery(Forward, int64(10)),
+			where:   "id > $3",
+			orderBy: "id ASC",
+			args:    []any{int64(10)},
+		},
+		{
+			name:    "mixed directions postgres",
+			keyset:  Keyset{Columns: mixed},
+			query:   seekQuery(Forward, "budi", int64(7)),
+			where:   "(name > $1 OR (name = $1 AND id < $2))",
+			orderBy: "name ASC, id DESC",
+			args:    []any{"budi", int64(7)},
+		},
+		{
+			name:    "mixed directions mysql",
+			keyset:  Keyset{Dialect: MySQL, Columns: mixed},
+			query:   seekQuery(Forward, "budi", int64(7)),
+			where:   "(name > ? OR (name = ? AND id < ?))",
+			orderBy: "name ASC, id DESC",
+			args:    []any{"budi", "budi", int64(7)},
+		},
+		{
+			name:    "nulls last, value",
+			keyset:  Keyset{Columns: nullable},
+			query:   seekQuery(Forward, "2026-01-01", int64(7)),
+			where:   "((deleted_at > $1 OR deleted_at IS NULL) OR (deleted_at = $1 AND id > $2))",
+			orderBy: "deleted_at ASC NULLS LAST, id ASC",
+			args:    []any{"2026-01-01", int64(7)},
+		},
+		{
+			name:    "nulls last, null",
+			keyset:  Keyset{Columns: nullable},
+			query:   seekQuery(Forward, nil, int64(7)),
+			where:   "(deleted_at IS NULL AND id > $1)",
+			orderBy: "deleted_at ASC NULLS LAST, id ASC",
+			args:    []any{int64(7)},
+		},
+		{
+			name:    "nulls last walked backward",
+			keyset:  Keyset{Columns: nullable},
+			query:   seekQuery(Backward, nil, int64(7)),
+			where:   "(deleted_at IS NOT NULL OR (deleted_at IS NULL AND id < $1))",
+			orderBy: "deleted_at DESC NULLS FIRST, id DESC",
+			args:    []any{int64(7)},
+		},
+		{
+			name:    "nulls in mysql",
+			keyset:  Keyset{Dialect: MySQL, Columns: []SortColumn{{Column: "deleted_at", Desc: true, Nulls: NullsFirst}, {Column: "id"}}},
+			query:   seekQuery(Forward, "2026-01-01", int64(7)),
+			where:   "(deleted_at < ? OR (deleted_at = ? AND id > ?))",
+			orderBy: "deleted_at IS NULL DESC, deleted_at DESC, id ASC",
+			args:    []any{"2026-01-01", "2026-01-01", int64(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.keyset.Build(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.where, c.Where)
+			assert.Equal(t, tt.orderBy, c.OrderBy)
+			assert.Equal(t, tt.args, c.Args)
+			assert.Equal(t, 21, c.Limit)
+		})
+	}
+}
+
+func TestKeyset_OnlyNullsRemain(t *testing.T) {
+	c, err := Keyset{Columns: []SortColumn{{Column: "deleted_at", Nulls: NullsLast}}}.Build(seekQuery(Forward, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "1 = 0", c.Where)
+}
+
+func TestKeyset_Errors(t *testing.T) {
+	_, err := Keyset{}.Build(seekQuery(Forward, 1))
+	assert.Error(t, err)
+
+	_, err = Keyset{Columns: []SortColumn{{Column: "id"}}}.Build(seekQuery(Forward, 1, 2))
+	var cursorErr *CursorError
+	require.ErrorAs(t, err, &cursorErr)
+	assert.Equal(t, "sort key mismatch", cursorErr.Reason)
+}
+
+func TestClause_SQL(t *testing.T) {
+	assert.Equal(t, "ORDER BY id ASC LIMIT 11", Clause{OrderBy: "id ASC", Limit: 11}.SQL())
+	assert.Equal(t, "WHERE id > $1 ORDER BY id ASC LIMIT 11", Clause{Where: "id > $1", OrderBy: "id ASC", Limit: 11}.SQL())
+}
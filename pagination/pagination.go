@@ -67,21 +67,13 @@ type Pagination struct {
 func New(pageStr, limitStr string, total int) Pagination {
 	// Parse strings to integers with defaults
 	page := parseInt(pageStr, DefaultPage)
-	limit := parseInt(limitStr, DefaultLimit)
+	limit := parseLimit(limitStr)
 
 	// Sanitize Inputs
 	// Ensure page is at least 1
 	if page < 1 {
 		page = DefaultPage
 	}
-	// Ensure limit is at least 1
-	if limit < MinLimit {
-		limit = DefaultLimit
-	}
-	// Cap limit at MaxLimit safely
-	if limit > MaxLimit {
-		limit = MaxLimit
-	}
 
 	// Initialize struct
 	p := Pagination{
@@ -161,6 +153,20 @@ func (p Pagination) Links(baseURL string) (map[string]string, error) {
 	return links, nil
 }
 
+// parseLimit parses a limit, defaulting when invalid and capping at MaxLimit
+func parseLimit(s string) int {
+	limit := parseInt(s, DefaultLimit)
+	// Ensure limit is at least 1
+	if limit < MinLimit {
+		limit = DefaultLimit
+	}
+	// Cap limit at MaxLimit safely
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return limit
+}
+
 // parseInt safely converts string to int with fallback
 func parseInt(s string, fallback int) int {
 	if s == "" {
@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAES(t *testing.T, key string) *cryptoutil.AESGCM {
+	t.Helper()
+	enc, err := cryptoutil.NewAESGCM(key)
+	require.NoError(t, err)
+	return enc
+}
+
+func TestSecureCodec_RoundTrip(t *testing.T) {
+	codec := SecureCodec{AES: testAES(t, "12345678901234567890123456789012"), TTL: time.Hour, Filter: "f1"}
+
+	token, err := codec.Encode(CursorState{Direction: Forward, Values: []any{"2026-01-02T03:04:05Z", 42}})
+	require.NoError(t, err)
+	assert.NotContains(t, token, "2026") // not readable
+
+	q, err := ParseCursorWith(codec, token, "20")
+	require.NoError(t, err)
+	assert.Equal(t, Forward, q.Direction)
+	assert.Equal(t, []any{"2026-01-02T03:04:05Z", int64(42)}, q.Values)
+
+	// Cursors of the resulting page use the same codec
+	_, c, err := CursorPage(q, []cursorRow{{ID: 43}}, cursorKey)
+	require.NoError(t, err)
+	_, err = codec.Decode(c.PrevCursor)
+	assert.NoError(t, err)
+}
+
+func TestSecureCodec_Rejects(t *testing.T) {
+	aes := testAES(t, "12345678901234567890123456789012")
+	state := CursorState{Direction: Forward, Values: []any{1}}
+
+	valid, _ := SecureCodec{AES: aes, Filter: "f1"}.Encode(state)
+	expiring, _ := SecureCodec{AES: aes, TTL: time.Millisecond}.Encode(state)
+	plain, _ := Base64Codec{}.Encode(state)
+	time.Sleep(5 * time.Millisecond)
+
+	// Flip one character in the middle of the ciphertext
+	tampered := []byte(valid)
+	i := len(tampered) / 2
+	if tampered[i] == 'A' {
+		tampered[i] = 'B'
+	} else {
+		tampered[i] = 'A'
+	}
+
+	tests := []struct {
+		name   string
+		codec  SecureCodec
+		token  string
+		reason string
+		cause  error
+	}{
+		{"tampered", SecureCodec{AES: aes, Filter: "f1"}, string(tampered), "tampered or foreign token", nil},
+		{"other key", SecureCodec{AES: testAES(t, strings.Repeat("k", 32)), Filter: "f1"}, valid, "tampered or foreign token", nil},
+		{"unsigned base64", SecureCodec{AES: aes}, plain, "tampered or foreign token", nil},
+		{"expired", SecureCodec{AES: aes}, expiring, "expired", ErrCursorExpired},
+		{"other filters", SecureCodec{AES: aes, Filter: "f2"}, valid, "filters changed", ErrCursorFilterMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCursorWith(tt.codec, tt.token, "10")
+
+			var cursorErr *CursorError
+			require.ErrorAs(t, err, &cursorErr)
+			assert.Equal(t, tt.reason, cursorErr.Reason)
+			assert.Equal(t, 400, cursorErr.StatusCode())
+			assert.True(t, errors.Is(err, ErrInvalidCursor))
+			if tt.cause != nil {
+				assert.True(t, errors.Is(err, tt.cause))
+			}
+		})
+	}
+}
+
+func TestFilterHash(t *testing.T) {
+	a := FilterHash(url.Values{"status": {"active"}, "sort": {"-created_at"}, "cursor": {"abc"}, "limit": {"10"}})
+	b := FilterHash(url.Values{"sort": {"-created_at"}, "status": {"active"}})
+	c := FilterHash(url.Values{"status": {"blocked"}, "sort": {"-created_at"}})
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Len(t, a, 16)
+}
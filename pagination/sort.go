@@ -0,0 +1,145 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SortParam is the query parameter read by Sorter.
+const SortParam = "sort"
+
+// DefaultMaxSortKeys is used when Sorter.MaxKeys is 0.
+const DefaultMaxSortKeys = 3
+
+// ErrInvalidSort is matched (errors.Is) by every *SortError.
+var ErrInvalidSort = errors.New("pagination: invalid sort")
+
+// SortError reports a ?sort= value that cannot be used.
+// It implements StatusCode() so response.FromError answers 400 Bad Request.
+type SortError struct {
+	Field  string // offending API field, "" when not field-specific
+	Reason string // e.g. "unknown field"
+}
+
+// Error implements the error interface.
+func (e *SortError) Error() string {
+	if e.Field == "" {
+		return "invalid sort: " + e.Reason
+	}
+	return fmt.Sprintf("invalid sort: %s %q", e.Reason, e.Field)
+}
+
+// StatusCode returns 400 Bad Request.
+func (e *SortError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Is makes errors.Is(err, ErrInvalidSort) match.
+func (e *SortError) Is(target error) bool {
+	return target == ErrInvalidSort
+}
+
+// Sort is a parsed, allow-listed ORDER BY.
+type Sort []SortColumn
+
+// SQL returns the sort as "ORDER BY ...", or "" when empty.
+// Column names come from the Sorter allow-list, never from the request.
+func (s Sort) SQL(d Dialect) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return "ORDER BY " + d.orderBy(s)
+}
+
+// Sorter parses ?sort= values like "-created_at,name": comma-separated API
+// field names, "-" for descending and an optional "+" for ascending.
+//
+// Example:
+//
+//	var userSort = pagination.Sorter{
+//	    Allowed: map[string]string{"created_at": "u.created_at", "name": "u.full_name"},
+//	    Nulls:   map[string]pagination.NullsOrder{"name": pagination.NullsLast},
+//	    Default: "-created_at",
+//	    Unique:  "u.id",
+//	}
+//
+//	sort, err := userSort.Parse(c.Query("sort"))
+//	if err != nil {
+//	    return response.FromError(ctx, err) // 400
+//	}
+//	query := "SELECT * FROM users u " + sort.SQL(pagination.PostgreSQL)
+type Sorter struct {
+	Allowed map[string]string     // API field → SQL column
+	Nulls   map[string]NullsOrder // API field → NULL placement, for nullable columns
+	Default string                // used when ?sort= is empty, e.g. "-created_at"
+	MaxKeys int                   // maximum number of keys (DefaultMaxSortKeys when 0)
+	Unique  string                // column appended when absent, making the order total for keyset pagination
+}
+
+// Parse validates sort against the allow-list and returns the ORDER BY.
+// Unknown, duplicate or empty keys and too many keys return a *SortError.
+func (s Sorter) Parse(sort string) (Sort, error) {
+	sort = strings.TrimSpace(sort)
+	if sort == "" {
+		sort = s.Default
+	}
+
+	maxKeys := s.MaxKeys
+	if maxKeys <= 0 {
+		maxKeys = DefaultMaxSortKeys
+	}
+
+	var out Sort
+	if sort != "" {
+		keys := strings.Split(sort, ",")
+		if len(keys) > maxKeys {
+			return nil, &SortError{Reason: fmt.Sprintf("at most %d keys allowed", maxKeys)}
+		}
+
+		seen := make(map[string]bool, len(keys))
+		for _, key := range keys {
+			// "+" arrives as a space when the client did not escape it
+			field := strings.TrimSpace(key)
+			desc := false
+			switch {
+			case strings.HasPrefix(field, "-"):
+				desc, field = true, field[1:]
+			case strings.HasPrefix(field, "+"):
+				field = field[1:]
+			}
+
+			if field == "" {
+				return nil, &SortError{Reason: "empty key"}
+			}
+			column, ok := s.Allowed[field]
+			if !ok {
+				return nil, &SortError{Field: field, Reason: "unknown field"}
+			}
+			if seen[field] {
+				return nil, &SortError{Field: field, Reason: "duplicate field"}
+			}
+			seen[field] = true
+
+			out = append(out, SortColumn{Column: column, Desc: desc, Nulls: s.Nulls[field]})
+		}
+	}
+
+	if s.Unique != "" && !out.has(s.Unique) {
+		// Follow the last key's direction so keysets can use a row-value comparison
+		desc := len(out) > 0 && out[len(out)-1].Desc
+		out = append(out, SortColumn{Column: s.Unique, Desc: desc})
+	}
+	return out, nil
+}
+
+// has reports whether the sort already orders by column.
+func (s Sort) has(column string) bool {
+	for _, col := range s {
+		if col.Column == column {
+			return true
+		}
+	}
+	return false
+}
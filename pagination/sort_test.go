@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSorter = Sorter{
+	Allowed: map[string]string{"created_at": "u.created_at", "name": "u.full_name", "score": "s.score"},
+	Nulls:   map[string]NullsOrder{"score": NullsLast},
+	Default: "-created_at",
+	Unique:  "u.id",
+}
+
+func TestSorter_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     string
+		expected Sort
+	}{
+		{"default", "", Sort{{Column: "u.created_at", Desc: true}, {Column: "u.id", Desc: true}}},
+		{"multi key", "-created_at,name", Sort{{Column: "u.created_at", Desc: true}, {Column: "u.full_name"}, {Column: "u.id"}}},
+		{"plus prefix", "+name", Sort{{Column: "u.full_name"}, {Column: "u.id"}}},
+		{"unescaped plus", " name , -score", Sort{{Column: "u.full_name"}, {Column: "s.score", Desc: true, Nulls: NullsLast}, {Column: "u.id", Desc: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testSorter.Parse(tt.sort)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSorter_ParseErrors(t *testing.T) {
+	tests := []struct {
+		sort    string
+		message string
+	}{
+		{"password", `invalid sort: unknown field "password"`},
+		{"name,-name", `invalid sort: duplicate field "name"`},
+		{"name,,score", "invalid sort: empty key"},
+		{"--name", `invalid sort: unknown field "-name"`},
+		{"name,score,created_at,name", "invalid sort: at most 3 keys allowed"},
+		{"u.full_name;DROP TABLE users", `invalid sort: unknown field "u.full_name;DROP TABLE users"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			_, err := testSorter.Parse(tt.sort)
+			require.Error(t, err)
+			assert.Equal(t, tt.message, err.Error())
+			assert.True(t, errors.Is(err, ErrInvalidSort))
+
+			var sortErr *SortError
+			require.ErrorAs(t, err, &sortErr)
+			assert.Equal(t, 400, sortErr.StatusCode())
+		})
+	}
+}
+
+func TestSorter_NoUnique(t *testing.T) {
+	got, err := Sorter{Allowed: map[string]string{"name": "name"}, MaxKeys: 1}.Parse("")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, "", got.SQL(PostgreSQL))
+}
+
+func TestSort_SQL(t *testing.T) {
+	sort, err := testSorter.Parse("-score,name")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ORDER BY s.score DESC NULLS LAST, u.full_name ASC, u.id ASC", sort.SQL(PostgreSQL))
+	assert.Equal(t, "ORDER BY s.score IS NULL ASC, s.score DESC, u.full_name ASC, u.id ASC", sort.SQL(MySQL))
+
+	// A parsed sort drives keyset pagination directly
+	c, err := Keyset{Columns: sort}.Build(seekQuery(Forward, 9.5, "budi", int64(7)))
+	require.NoError(t, err)
+	assert.Equal(t, []any{9.5, "budi", int64(7)}, c.Args)
+}
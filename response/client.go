@@ -0,0 +1,128 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+)
+
+// ErrInvalidEnvelope is returned when a body is neither a meta/data envelope
+// nor RFC 9457 problem details.
+var ErrInvalidEnvelope = errors.New("response: invalid envelope")
+
+// Typed is the client-side, strongly typed form of Response.
+// Use it (or Decode) in service-to-service clients instead of Response,
+// whose Data is untyped.
+type Typed[T any] struct {
+	Meta       Meta                   `json:"meta"`
+	Data       T                      `json:"data"`
+	Errors     []FieldError           `json:"errors,omitempty"`
+	Pagination *pagination.Pagination `json:"pagination,omitempty"`
+	Cursor     *pagination.Cursor     `json:"cursor,omitempty"`
+}
+
+// APIError is returned by Decode for non-success envelopes.
+type APIError struct {
+	StatusCode int          // HTTP status code from meta
+	Message    string       // meta.message (or problem detail)
+	RequestID  string       // correlation ID, quote it when reporting issues
+	ErrorCode  string       // machine-readable code, if any
+	Errors     []FieldError // field-level validation errors, if any
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("api error %d (%s): %s [request_id=%s]", e.StatusCode, e.ErrorCode, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("api error %d: %s [request_id=%s]", e.StatusCode, e.Message, e.RequestID)
+}
+
+// Decode reads an envelope from r and returns its typed data.
+// Non-success envelopes (and problem+json bodies) become an *APIError;
+// bodies without a valid meta return ErrInvalidEnvelope.
+//
+// Example:
+//
+//	user, err := response.Decode[User](httpResp.Body)
+//	var apiErr *response.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == 404 { ... }
+func Decode[T any](r io.Reader) (T, error) {
+	env, err := DecodeTyped[T](r)
+	return env.Data, err
+}
+
+// DecodeTyped is like Decode but returns the whole typed envelope,
+// including meta, pagination and cursor.
+func DecodeTyped[T any](r io.Reader) (Typed[T], error) {
+	var out Typed[T]
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return out, fmt.Errorf("response: read body: %w", err)
+	}
+
+	// Decode meta first so a failed envelope never fails on an unexpected data shape
+	var raw struct {
+		Meta       *Meta                  `json:"meta"`
+		Data       json.RawMessage        `json:"data"`
+		Errors     []FieldError           `json:"errors"`
+		Pagination *pagination.Pagination `json:"pagination"`
+		Cursor     *pagination.Cursor     `json:"cursor"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return out, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+
+	if raw.Meta == nil {
+		return out, problemError(body)
+	}
+	if raw.Meta.StatusCode == 0 {
+		return out, fmt.Errorf("%w: missing meta.status_code", ErrInvalidEnvelope)
+	}
+
+	out.Meta = *raw.Meta
+	out.Errors = raw.Errors
+	out.Pagination = raw.Pagination
+	out.Cursor = raw.Cursor
+
+	if !raw.Meta.Success {
+		return out, &APIError{
+			StatusCode: raw.Meta.StatusCode,
+			Message:    raw.Meta.Message,
+			RequestID:  raw.Meta.RequestID,
+			ErrorCode:  raw.Meta.ErrorCode,
+			Errors:     raw.Errors,
+		}
+	}
+
+	if len(raw.Data) > 0 {
+		if err := json.Unmarshal(raw.Data, &out.Data); err != nil {
+			return out, fmt.Errorf("response: decode data: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// problemError converts a problem+json body into an *APIError.
+func problemError(body []byte) error {
+	var p Problem
+	if err := json.Unmarshal(body, &p); err != nil || p.Status == 0 {
+		return fmt.Errorf("%w: missing meta", ErrInvalidEnvelope)
+	}
+
+	apiErr := &APIError{StatusCode: p.Status, Message: p.Detail}
+	apiErr.RequestID, _ = p.Extensions["request_id"].(string)
+	apiErr.ErrorCode, _ = p.Extensions["error_code"].(string)
+
+	// Field errors arrive as generic JSON; round-trip them into FieldError
+	if raw, ok := p.Extensions["errors"]; ok {
+		if b, err := json.Marshal(raw); err == nil {
+			_ = json.Unmarshal(b, &apiErr.Errors)
+		}
+	}
+	return apiErr
+}
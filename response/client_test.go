@@ -0,0 +1,104 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestDecode_Success(t *testing.T) {
+	body, _ := json.Marshal(OK(context.Background(), "user found", testUser{ID: 1, Name: "Budi"}))
+
+	user, err := Decode[testUser](strings.NewReader(string(body)))
+
+	require.NoError(t, err)
+	assert.Equal(t, testUser{ID: 1, Name: "Budi"}, user)
+}
+
+func TestDecodeTyped_Paginated(t *testing.T) {
+	resp := Paginated(context.Background(), "users", []testUser{{ID: 1}, {ID: 2}}, pagination.New("1", "2", 5))
+	body, _ := json.Marshal(resp)
+
+	env, err := DecodeTyped[[]testUser](strings.NewReader(string(body)))
+
+	require.NoError(t, err)
+	assert.Len(t, env.Data, 2)
+	require.NotNil(t, env.Pagination)
+	assert.Equal(t, 3, env.Pagination.TotalPages)
+	assert.Equal(t, 200, env.Meta.StatusCode)
+}
+
+func TestDecodeTyped_CursorPaginated(t *testing.T) {
+	c := pagination.Cursor{Limit: 2, HasMore: true, NextCursor: "n1"}
+	body, _ := json.Marshal(CursorPaginated(context.Background(), "users", []testUser{{ID: 1}, {ID: 2}}, c))
+
+	env, err := DecodeTyped[[]testUser](strings.NewReader(string(body)))
+
+	require.NoError(t, err)
+	assert.Len(t, env.Data, 2)
+	assert.Nil(t, env.Pagination)
+	require.NotNil(t, env.Cursor)
+	assert.Equal(t, c, *env.Cursor)
+}
+
+func TestDecode_ErrorEnvelope(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-client-1")
+	body, _ := json.Marshal(NotFound(ctx, "user not found"))
+
+	_, err := Decode[testUser](strings.NewReader(string(body)))
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 404, apiErr.StatusCode)
+	assert.Equal(t, "user not found", apiErr.Message)
+	assert.Equal(t, "req-client-1", apiErr.RequestID)
+	assert.Equal(t, "api error 404: user not found [request_id=req-client-1]", apiErr.Error())
+}
+
+func TestDecode_ProblemBody(t *testing.T) {
+	req := httptest.NewRequest("POST", "/users", nil)
+	req.Header.Set("Accept", ContentTypeProblem)
+	ctx := activity.WithRequestID(req.Context(), "req-client-2")
+	rec := httptest.NewRecorder()
+
+	resp := UnprocessableEntity(ctx, "validation failed")
+	resp.Errors = []FieldError{{Field: "name", Rule: "required", Message: "name is required"}}
+	require.NoError(t, Render(rec, req, resp))
+
+	_, err := Decode[testUser](rec.Body)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 422, apiErr.StatusCode)
+	assert.Equal(t, "req-client-2", apiErr.RequestID)
+	assert.Equal(t, resp.Errors, apiErr.Errors)
+}
+
+func TestDecode_InvalidEnvelope(t *testing.T) {
+	for _, body := range []string{`not json`, `{"data":{"id":1}}`, `{"meta":{"success":true}}`} {
+		_, err := Decode[testUser](strings.NewReader(body))
+		assert.ErrorIs(t, err, ErrInvalidEnvelope, body)
+	}
+}
+
+func TestDecode_DataTypeMismatch(t *testing.T) {
+	body, _ := json.Marshal(OK(context.Background(), "ok", "not a user"))
+
+	_, err := Decode[testUser](strings.NewReader(string(body)))
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidEnvelope)
+}
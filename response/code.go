@@ -0,0 +1,86 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ErrorCode is a registered machine-readable error code.
+// Clients branch on Code; Message is only the default human-readable text.
+type ErrorCode struct {
+	Code    string // e.g. "PAYMENT_INSUFFICIENT_BALANCE"
+	Status  int    // default HTTP status code
+	Message string // default message, lowercase
+}
+
+// codes is the service-wide error catalog.
+var (
+	codesMu sync.RWMutex
+	codes   = make(map[string]ErrorCode)
+)
+
+// RegisterCode adds an error code to the catalog.
+// Call it at startup (e.g. from init). Like http.Handle, it panics on an empty
+// code, a status outside 400-599, or a code that is already registered,
+// because these are programming errors.
+//
+// Example:
+//
+//	func init() {
+//	    response.RegisterCode("PAYMENT_INSUFFICIENT_BALANCE", 422, "insufficient balance")
+//	}
+func RegisterCode(code string, status int, message string) {
+	if code == "" {
+		panic("response: empty error code")
+	}
+	if status < 400 || status > 599 {
+		panic(fmt.Sprintf("response: invalid status %d for error code %s", status, code))
+	}
+
+	codesMu.Lock()
+	defer codesMu.Unlock()
+
+	if _, exists := codes[code]; exists {
+		panic("response: duplicate error code " + code)
+	}
+	codes[code] = ErrorCode{Code: code, Status: status, Message: message}
+}
+
+// LookupCode returns the registered definition of code.
+func LookupCode(code string) (ErrorCode, bool) {
+	codesMu.RLock()
+	defer codesMu.RUnlock()
+
+	ec, ok := codes[code]
+	return ec, ok
+}
+
+// FromCode builds an error response from a registered code,
+// using its default status and message.
+// An unregistered code is a programming error and yields InternalError.
+//
+// Example:
+//
+//	return response.FromCode(ctx, "PAYMENT_INSUFFICIENT_BALANCE")
+func FromCode(ctx context.Context, code string) Response {
+	ec, ok := LookupCode(code)
+	if !ok {
+		return InternalError(ctx)
+	}
+	return FromCodeMessage(ctx, code, ec.Message)
+}
+
+// FromCodeMessage builds an error response from a registered code,
+// overriding the default message.
+// An unregistered code is a programming error and yields InternalError.
+func FromCodeMessage(ctx context.Context, code, message string) Response {
+	ec, ok := LookupCode(code)
+	if !ok {
+		return InternalError(ctx)
+	}
+
+	meta := NewMeta(ctx, false, message, ec.Status)
+	meta.ErrorCode = ec.Code
+	return Response{Meta: meta}
+}
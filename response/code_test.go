@@ -0,0 +1,64 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterCode(t *testing.T) {
+	RegisterCode("TEST_REGISTER_OK", 422, "insufficient balance")
+
+	ec, ok := LookupCode("TEST_REGISTER_OK")
+	assert.True(t, ok)
+	assert.Equal(t, ErrorCode{Code: "TEST_REGISTER_OK", Status: 422, Message: "insufficient balance"}, ec)
+
+	_, ok = LookupCode("TEST_UNKNOWN")
+	assert.False(t, ok)
+}
+
+func TestRegisterCode_Panics(t *testing.T) {
+	RegisterCode("TEST_REGISTER_DUP", 409, "duplicate")
+
+	assert.Panics(t, func() { RegisterCode("", 400, "empty") })
+	assert.Panics(t, func() { RegisterCode("TEST_REGISTER_2XX", 200, "not an error") })
+	assert.Panics(t, func() { RegisterCode("TEST_REGISTER_DUP", 409, "duplicate") })
+}
+
+func TestFromCode(t *testing.T) {
+	RegisterCode("TEST_PAYMENT_INSUFFICIENT_BALANCE", 422, "insufficient balance")
+	ctx := activity.WithRequestID(context.Background(), "req-code-1")
+
+	resp := FromCode(ctx, "TEST_PAYMENT_INSUFFICIENT_BALANCE")
+	assert.False(t, resp.Meta.Success)
+	assert.Equal(t, 422, resp.Meta.StatusCode)
+	assert.Equal(t, "insufficient balance", resp.Meta.Message)
+	assert.Equal(t, "TEST_PAYMENT_INSUFFICIENT_BALANCE", resp.Meta.ErrorCode)
+	assert.Equal(t, "req-code-1", resp.Meta.RequestID)
+
+	data, _ := json.Marshal(resp)
+	assert.Contains(t, string(data), `"error_code":"TEST_PAYMENT_INSUFFICIENT_BALANCE"`)
+
+	resp = FromCodeMessage(ctx, "TEST_PAYMENT_INSUFFICIENT_BALANCE", "balance is 5.000,00")
+	assert.Equal(t, "balance is 5.000,00", resp.Meta.Message)
+	assert.Equal(t, 422, resp.Meta.StatusCode)
+
+	assert.Equal(t, "req-code-1", resp.Problem().Extensions["request_id"])
+	assert.Equal(t, "TEST_PAYMENT_INSUFFICIENT_BALANCE", resp.Problem().Extensions["error_code"])
+}
+
+func TestFromCode_Unregistered(t *testing.T) {
+	resp := FromCode(context.Background(), "TEST_NEVER_REGISTERED")
+
+	assert.Equal(t, 500, resp.Meta.StatusCode)
+	assert.Empty(t, resp.Meta.ErrorCode)
+}
+
+func TestMeta_ErrorCodeOmittedWhenEmpty(t *testing.T) {
+	data, _ := json.Marshal(OK(context.Background(), "ok", nil))
+
+	assert.NotContains(t, string(data), "error_code")
+}
@@ -0,0 +1,264 @@
+package response
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCompressMinSize is the body size below which CompressMiddleware
+// sends responses uncompressed; the overhead is not worth it for tiny envelopes.
+const DefaultCompressMinSize = 1024
+
+// compressor is the part of *gzip.Writer and *flate.Writer used here.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressorPools reuse compressors per Content-Encoding.
+var compressorPools = map[string]*sync.Pool{
+	"gzip": {New: func() any {
+		return gzip.NewWriter(io.Discard)
+	}},
+	"deflate": {New: func() any {
+		w, _ := flate.NewWriter(io.Discard, flate.DefaultCompression)
+		return w
+	}},
+}
+
+// CompressMiddleware compresses responses with gzip or deflate, negotiated
+// from Accept-Encoding. Bodies smaller than minSize (DefaultCompressMinSize
+// when minSize <= 0), responses that already have a Content-Encoding and
+// already-compressed content types (images, audio, video, archives) are sent
+// as-is. It always adds "Vary: Accept-Encoding" and appends the coding to the
+// ETag of compressed responses ("abc" → "abc-gzip"), so each encoding has its
+// own strong tag; CheckIfMatch and If-None-Match accept either form.
+//
+// Flushing is supported: StreamNDJSON / StreamSSE records are compressed
+// and flushed one by one, so streams keep working behind the middleware.
+//
+// Example:
+//
+//	handler = response.CompressMiddleware(0)(mux)
+func CompressMiddleware(minSize int) func(http.Handler) http.Handler {
+	if minSize <= 0 {
+		minSize = DefaultCompressMinSize
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addVary(w.Header(), "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        minSize,
+				ifNoneMatch:    r.Header.Get("If-None-Match"),
+			}
+			next.ServeHTTP(cw, r)
+			cw.close()
+		})
+	}
+}
+
+// negotiateEncoding picks "gzip" or "deflate" from an Accept-Encoding header,
+// honoring q-values (gzip wins ties). It returns "" when neither is acceptable.
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "*" {
+			coding = "gzip"
+		}
+		if _, ok := compressorPools[coding]; !ok {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ || (q == bestQ && q > 0 && coding == "gzip") {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressWriter buffers the body until minSize bytes are known, then
+// decides whether to compress it.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding    string
+	minSize     int
+	ifNoneMatch string // request header, to repeat the coded ETag on 304
+
+	status  int        // status passed to WriteHeader, sent once decided
+	buf     []byte     // body written before the decision
+	decided bool       // headers have been sent
+	comp    compressor // nil when the body is sent uncompressed
+}
+
+// WriteHeader records the status; it is sent once the encoding is decided.
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided || cw.status != 0 {
+		return
+	}
+	if status < http.StatusOK {
+		// Informational responses (e.g. 103 Early Hints) pass straight through
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	cw.status = status
+	if !bodyAllowed(status) {
+		_ = cw.decide(false)
+	}
+}
+
+// Write buffers p until the size threshold is reached.
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) >= cw.minSize {
+			if err := cw.decide(true); err != nil {
+				return 0, err
+			}
+		}
+		return len(p), nil
+	}
+
+	if cw.comp != nil {
+		return cw.comp.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush sends everything written so far. A body that is flushed before
+// reaching the threshold is a stream and is compressed regardless of size.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		if err := cw.decide(true); err != nil {
+			return
+		}
+	}
+	if cw.comp != nil {
+		_ = cw.comp.Flush()
+	}
+	_ = http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// decide sends the headers and the buffered body, compressed when allowed
+// and the content type is worth compressing.
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+	h := cw.Header()
+
+	if compress && bodyAllowed(cw.status) && h.Get("Content-Encoding") == "" {
+		// Sniff before compressing, net/http would otherwise sniff compressed bytes
+		if h.Get("Content-Type") == "" && len(cw.buf) > 0 {
+			h.Set("Content-Type", http.DetectContentType(cw.buf))
+		}
+		if compressible(h.Get("Content-Type")) {
+			cw.comp = compressorPools[cw.encoding].Get().(compressor)
+			cw.comp.Reset(cw.ResponseWriter)
+			h.Set("Content-Encoding", cw.encoding)
+			h.Del("Content-Length")
+			// The compressed bytes are a different representation: give them
+			// their own strong tag
+			if tag := h.Get("ETag"); tag != "" {
+				h.Set("ETag", etagWithCoding(tag, cw.encoding))
+			}
+		}
+	}
+
+	if cw.status == http.StatusNotModified {
+		// Repeat the tag of the compressed representation the client cached
+		if tag := h.Get("ETag"); tag != "" {
+			if coded := etagWithCoding(tag, cw.encoding); slices.Contains(splitETags(cw.ifNoneMatch), coded) {
+				h.Set("ETag", coded)
+			}
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := cw.Write(buf)
+	return err
+}
+
+// close decides on bodies that never reached the threshold and finishes the
+// compressed stream.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if cw.status == 0 {
+			// Nothing written: net/http sends the default response
+			return
+		}
+		_ = cw.decide(len(cw.buf) >= cw.minSize)
+	}
+	if cw.comp != nil {
+		_ = cw.comp.Close()
+		cw.comp.Reset(io.Discard)
+		compressorPools[cw.encoding].Put(cw.comp)
+		cw.comp = nil
+	}
+}
+
+// compressible reports whether a content type benefits from compression.
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+
+	kind, subtype, _ := strings.Cut(mediaType, "/")
+	switch kind {
+	case "image":
+		// SVG is text
+		return subtype == "svg+xml"
+	case "audio", "video":
+		return false
+	}
+	switch mediaType {
+	case "application/zip", "application/gzip", "application/x-gzip",
+		"application/zstd", "application/x-bzip2", "application/x-7z-compressed",
+		"application/x-rar-compressed", "application/pdf",
+		"font/woff", "font/woff2":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,259 @@
+package response
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"gzip, deflate, br", "gzip"},
+		{"deflate, gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"gzip;q=0, deflate;q=0", ""},
+		{"*", "gzip"},
+		{"br, identity", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateEncoding(tt.header))
+		})
+	}
+}
+
+func TestCompressMiddleware_LargeEnvelope(t *testing.T) {
+	items := make([]string, 200)
+	for i := range items {
+		items[i] = "item-value"
+	}
+	handler := CompressMiddleware(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = Render(w, r, OK(r.Context(), "ok", items))
+	}))
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, 200, rec.Code)
+			assert.Equal(t, encoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+			assert.Equal(t, []string{"Accept-Encoding", "Accept"}, rec.Header().Values("Vary"))
+
+			var r io.Reader
+			if encoding == "gzip" {
+				gz, err := gzip.NewReader(rec.Body)
+				require.NoError(t, err)
+				r = gz
+			} else {
+				r = flate.NewReader(rec.Body)
+			}
+			plain, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Contains(t, string(plain), `"message":"ok"`)
+			assert.Greater(t, len(plain), rec.Body.Len())
+		})
+	}
+}
+
+func TestCompressMiddleware_SkipsBody(t *testing.T) {
+	large := strings.Repeat("x", 2048)
+	tests := []struct {
+		name    string
+		accept  string
+		method  string
+		handler http.HandlerFunc
+	}{
+		{"small body", "gzip", "GET", func(w http.ResponseWriter, r *http.Request) {
+			_ = Render(w, r, OK(r.Context(), "ok", nil))
+		}},
+		{"no accept-encoding", "", "GET", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, large)
+		}},
+		{"head", "gzip", "HEAD", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, large)
+		}},
+		{"already compressed type", "gzip", "GET", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = io.WriteString(w, large)
+		}},
+		{"already encoded", "gzip", "GET", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			_, _ = io.WriteString(w, large)
+		}},
+		{"no content", "gzip", "GET", func(w http.ResponseWriter, r *http.Request) {
+			_ = Render(w, r, NoContent(r.Context()))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept-Encoding", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			CompressMiddleware(0)(tt.handler).ServeHTTP(rec, req)
+
+			assert.NotContains(t, []string{"gzip", "deflate"}, rec.Header().Get("Content-Encoding"))
+			assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+		})
+	}
+}
+
+func TestCompressMiddleware_CodingETag(t *testing.T) {
+	items := make([]string, 200)
+	for i := range items {
+		items[i] = "item-value"
+	}
+	handler := CompressMiddleware(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = Render(w, r, OK(r.Context(), "ok", items), WithETag())
+	}))
+	tag, _ := ETag(items)
+	gzipTag := strings.TrimSuffix(tag, `"`) + `-gzip"`
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, gzipTag, rec.Header().Get("ETag"))
+
+	// Identity responses keep the plain tag
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, tag, rec.Header().Get("ETag"))
+
+	// The coded tag revalidates, and the 304 repeats it
+	req.Header.Set("If-None-Match", gzipTag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, 304, rec.Code)
+	assert.Equal(t, gzipTag, rec.Header().Get("ETag"))
+}
+
+func TestCompressMiddleware_ETagPassesIfMatch(t *testing.T) {
+	product := testProduct{ID: 1, Price: 100}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /products/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = Render(w, r, OK(r.Context(), "product", product), WithETag())
+	})
+	mux.HandleFunc("PUT /products/1", func(w http.ResponseWriter, r *http.Request) {
+		if resp, ok := CheckIfMatch(r, product); !ok {
+			_ = Render(w, r, resp)
+			return
+		}
+		_ = Render(w, r, OK(r.Context(), "updated", product))
+	})
+	// Small minimum so the single product is compressed
+	handler := CompressMiddleware(1)(mux)
+
+	get := httptest.NewRequest("GET", "/products/1", nil)
+	get.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, get)
+	require.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	tag := rec.Header().Get("ETag")
+	assert.True(t, strings.HasSuffix(tag, `-gzip"`))
+
+	put := httptest.NewRequest("PUT", "/products/1", nil)
+	put.Header.Set("Accept-Encoding", "gzip")
+	put.Header.Set("If-Match", tag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, put)
+	assert.Equal(t, 200, rec.Code)
+
+	// A stale coded tag still fails
+	put.Header.Set("If-Match", `"stale-gzip"`)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, put)
+	assert.Equal(t, 412, rec.Code)
+}
+
+func TestCompressMiddleware_SniffsBeforeCompressing(t *testing.T) {
+	handler := CompressMiddleware(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "<html><body>"+strings.Repeat("hello ", 50)+"</body></html>")
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+}
+
+func TestCompressMiddleware_Stream(t *testing.T) {
+	release := make(chan struct{})
+	handler := CompressMiddleware(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seq := func(yield func(int, error) bool) {
+			if !yield(1, nil) {
+				return
+			}
+			<-release // the first record must reach the client before the stream ends
+			yield(2, nil)
+		}
+		_ = StreamNDJSON(context.Background(), w, seq)
+	}))
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+	gz, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	lines := bufio.NewScanner(gz)
+
+	var got []string
+	for range 2 {
+		require.True(t, lines.Scan())
+		got = append(got, lines.Text())
+	}
+	close(release)
+	for lines.Scan() {
+		got = append(got, lines.Text())
+	}
+
+	require.Len(t, got, 4)
+	assert.Contains(t, got[0], "stream started")
+	assert.Equal(t, `{"data":1}`, got[1])
+	assert.Equal(t, `{"data":2}`, got[2])
+	assert.Contains(t, got[3], "stream completed")
+	assert.True(t, slices.Contains(resp.Header.Values("Vary"), "Accept-Encoding"))
+}
+
+func TestCompressible(t *testing.T) {
+	assert.True(t, compressible(ContentTypeJSON))
+	assert.True(t, compressible("image/svg+xml"))
+	assert.True(t, compressible(""))
+	assert.False(t, compressible("image/jpeg"))
+	assert.False(t, compressible("video/mp4"))
+	assert.False(t, compressible("application/zip"))
+}
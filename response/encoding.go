@@ -0,0 +1,239 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ContentTypeXML is the content type of the built-in XML encoder.
+const ContentTypeXML = "application/xml; charset=utf-8"
+
+// EncodeFunc encodes an envelope (Response or Problem).
+// Functions like cbor.Marshal or msgpack.Marshal can be used as-is.
+type EncodeFunc func(v any) ([]byte, error)
+
+// encoder is a registered representation of the envelope.
+type encoder struct {
+	mediaType   string     // e.g. "application/xml"
+	contentType string     // Content-Type header value
+	encode      EncodeFunc // body encoder
+}
+
+// encoders are the available representations; the first one (JSON) is the default.
+var (
+	encodersMu sync.RWMutex
+	encoders   = []encoder{
+		{mediaType: "application/json", contentType: ContentTypeJSON, encode: json.Marshal},
+		{mediaType: "application/xml", contentType: ContentTypeXML, encode: encodeXML},
+		{mediaType: "text/xml", contentType: "text/xml; charset=utf-8", encode: encodeXML},
+	}
+)
+
+// RegisterEncoder makes an additional envelope encoding available through
+// content negotiation, or replaces the encoder of an existing media type.
+// JSON always stays the default for clients that send no Accept header or */*.
+// Call it at startup.
+//
+// Example:
+//
+//	response.RegisterEncoder("application/cbor", cbor.Marshal)
+//	response.RegisterEncoder("application/msgpack", msgpack.Marshal)
+func RegisterEncoder(mediaType string, fn EncodeFunc) {
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	for i, e := range encoders {
+		if e.mediaType == mediaType {
+			encoders[i].encode = fn
+			return
+		}
+	}
+	encoders = append(encoders, encoder{mediaType: mediaType, contentType: mediaType, encode: fn})
+}
+
+// defaultEncoder returns the JSON encoder.
+func defaultEncoder() encoder {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	return encoders[0]
+}
+
+// negotiate picks the encoder for req from its Accept header.
+// It reports false when the client accepts none of the registered encodings.
+func negotiate(req *http.Request) (encoder, bool) {
+	if req == nil || req.Header.Get("Accept") == "" {
+		return defaultEncoder(), true
+	}
+
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	for _, r := range parseAccept(req.Header.Get("Accept")) {
+		switch {
+		case r.mediaType == "*/*":
+			return encoders[0], true
+		case r.mediaType == ContentTypeProblem:
+			// Problem details are a JSON dialect of the envelope
+			return encoders[0], true
+		case strings.HasSuffix(r.mediaType, "/*"):
+			prefix := strings.TrimSuffix(r.mediaType, "*")
+			for _, e := range encoders {
+				if strings.HasPrefix(e.mediaType, prefix) {
+					return e, true
+				}
+			}
+		default:
+			for _, e := range encoders {
+				if e.mediaType == r.mediaType {
+					return e, true
+				}
+			}
+		}
+	}
+	return encoder{}, false
+}
+
+// mediaRange is one entry of an Accept header.
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept returns the media ranges of an Accept header with q > 0,
+// ordered by preference (highest q first, header order on ties).
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, mediaRange{mediaType: strings.ToLower(mt), q: q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges
+}
+
+// addVary appends value to the Vary header unless it is already listed.
+func addVary(h http.Header, value string) {
+	for _, v := range h.Values("Vary") {
+		for _, existing := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(existing), value) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", value)
+}
+
+// encodeXML encodes v as XML by translating its JSON form, so every value
+// that encodes to JSON (maps included) also encodes to XML. Member order is
+// preserved; array elements become <item> children. The root is <response>.
+//
+// Example output:
+//
+//	<?xml version="1.0" encoding="UTF-8"?>
+//	<response><meta><success>true</success>...</meta><data><item><id>1</id></item></data></response>
+func encodeXML(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	if err := jsonToXML(dec, enc, "response"); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonToXML converts the next JSON value of dec into an element called name.
+func jsonToXML(dec *json.Decoder, enc *xml.Encoder, name string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: xmlName(name)}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		for dec.More() {
+			child := "item"
+			if t == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child = key.(string)
+			}
+			if err := jsonToXML(dec, enc, child); err != nil {
+				return err
+			}
+		}
+		// Consume the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	case nil:
+		// null → empty element
+	default:
+		if err := enc.EncodeToken(xml.CharData(fmt.Sprint(t))); err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+// xmlName turns a JSON member name into a valid XML element name.
+func xmlName(s string) string {
+	if s == "" {
+		return "_"
+	}
+	var b strings.Builder
+	for i, r := range s {
+		valid := unicode.IsLetter(r) || r == '_' ||
+			(i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'))
+		if !valid {
+			if i == 0 && unicode.IsDigit(r) {
+				b.WriteRune('_')
+				b.WriteRune(r)
+				continue
+			}
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
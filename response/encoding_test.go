@@ -0,0 +1,109 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	RegisterEncoder("application/x-test", func(v any) ([]byte, error) {
+		b, err := json.Marshal(v)
+		return append([]byte("TEST:"), b...), err
+	})
+}
+
+func TestRender_XML(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req.Header.Set("Accept", "application/xml")
+	ctx := activity.WithRequestID(req.Context(), "req-xml-1")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(ctx, "user found", []testUser{{ID: 1, Name: "Budi"}})))
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, ContentTypeXML, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+	assert.Contains(t, rec.Body.String(), `<?xml version="1.0" encoding="UTF-8"?>`)
+	assert.Contains(t, rec.Body.String(), "<request_id>req-xml-1</request_id>")
+	assert.Contains(t, rec.Body.String(), "<data><item><id>1</id><name>Budi</name></item></data>")
+}
+
+func TestEncodeXML(t *testing.T) {
+	body, err := encodeXML(map[string]any{"1st key": nil, "ok": true, "n": 1.5})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "<_1st_key></_1st_key>")
+	assert.Contains(t, string(body), "<ok>true</ok>")
+	assert.Contains(t, string(body), "<n>1.5</n>")
+
+	_, err = encodeXML(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestRender_Negotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"no accept", "", 200, ContentTypeJSON},
+		{"wildcard", "*/*", 200, ContentTypeJSON},
+		{"type wildcard", "text/*", 200, "text/xml; charset=utf-8"},
+		{"q ordering", "application/json;q=0.5, application/xml", 200, ContentTypeXML},
+		{"custom encoder", "application/x-test", 200, "application/x-test"},
+		{"unsupported then json", "application/cbor, application/json;q=0.1", 200, ContentTypeJSON},
+		{"not acceptable", "application/cbor", 406, ContentTypeJSON},
+		{"refused json", "application/json;q=0", 406, ContentTypeJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			ctx := activity.WithRequestID(req.Context(), "req-neg-1")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, Render(rec, req, OK(ctx, "ok", "payload")))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, "req-neg-1", rec.Header().Get(HeaderRequestID))
+		})
+	}
+}
+
+func TestRender_CustomEncoderBody(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", "application/x-test")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(req.Context(), "ok", nil)))
+	assert.Regexp(t, `^TEST:\{"meta":`, rec.Body.String())
+}
+
+func TestRender_ProblemOnlyForJSON(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req.Header.Set("Accept", "application/xml, application/problem+json;q=0.5")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, NotFound(req.Context(), "user not found")))
+
+	assert.Equal(t, 404, rec.Code)
+	assert.Equal(t, ContentTypeXML, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<message>user not found</message>")
+}
+
+func TestAddVary(t *testing.T) {
+	rec := httptest.NewRecorder()
+	addVary(rec.Header(), "Accept")
+	addVary(rec.Header(), "accept")
+	addVary(rec.Header(), "Accept-Language")
+	assert.Equal(t, []string{"Accept", "Accept-Language"}, rec.Header().Values("Vary"))
+}
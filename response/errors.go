@@ -0,0 +1,154 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/go-playground/validator/v10"
+)
+
+// StatusCoder is implemented by domain errors that choose their own HTTP status.
+// FromError uses the Error() of the implementing error (not of the wrapping
+// chain) as the message for 4xx codes and the generic status text for 5xx
+// codes, so internal details never leak to clients. Codes outside 400-599
+// are treated as an InternalError.
+//
+// Example:
+//
+//	type InsufficientBalanceError struct{ Needed int64 }
+//
+//	func (e InsufficientBalanceError) Error() string   { return "insufficient balance" }
+//	func (e InsufficientBalanceError) StatusCode() int { return 422 }
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// errorMapping maps every error matched by match to a status and message.
+// match returns the matched error of the chain, used for empty messages.
+type errorMapping struct {
+	match   func(error) (error, bool)
+	status  int
+	message string
+}
+
+// errorMappings is the service-wide registry used by FromError, in registration order.
+var (
+	errorMappingsMu sync.RWMutex
+	errorMappings   []errorMapping
+)
+
+// RegisterError maps a sentinel error (matched with errors.Is) to a status and message.
+// An empty message falls back to target.Error(). Call it at startup; it panics
+// on a nil target or a status outside 400-599.
+//
+// Example:
+//
+//	response.RegisterError(sql.ErrNoRows, 404, "resource not found")
+func RegisterError(target error, status int, message string) {
+	if target == nil {
+		panic("response: nil error target")
+	}
+	addErrorMapping(errorMapping{
+		match:   func(err error) (error, bool) { return target, errors.Is(err, target) },
+		status:  status,
+		message: message,
+	})
+}
+
+// RegisterErrorType maps every error whose chain contains a T (matched with errors.As)
+// to a status and message. An empty message falls back to the Error() of the
+// matched T.
+// Call it at startup; it panics on a status outside 400-599.
+//
+// Example:
+//
+//	response.RegisterErrorType[*pgconn.PgError](409, "conflict")
+func RegisterErrorType[T error](status int, message string) {
+	addErrorMapping(errorMapping{
+		match: func(err error) (error, bool) {
+			var target T
+			ok := errors.As(err, &target)
+			return target, ok
+		},
+		status:  status,
+		message: message,
+	})
+}
+
+// addErrorMapping validates and appends m to the registry.
+func addErrorMapping(m errorMapping) {
+	if m.status < 400 || m.status > 599 {
+		panic(fmt.Sprintf("response: invalid status %d for error mapping", m.status))
+	}
+
+	errorMappingsMu.Lock()
+	defer errorMappingsMu.Unlock()
+	errorMappings = append(errorMappings, m)
+}
+
+// FromError converts an error into a response. Resolution order:
+//  1. nil → Success
+//  2. *pagination.FilterError → ValidationFailed
+//  3. an error in the chain implementing StatusCoder (400-599 only)
+//  4. validator.ValidationErrors → ValidationFailed
+//  5. mappings added with RegisterError / RegisterErrorType (first match wins)
+//  6. InternalError
+//
+// Example:
+//
+//	user, err := repo.FindUser(ctx, id)
+//	if err != nil {
+//	    return response.FromError(ctx, err)
+//	}
+func FromError(ctx context.Context, err error) Response {
+	if err == nil {
+		return Success(ctx, nil)
+	}
+
+	// Checked before StatusCoder so the rejected parameters are listed
+	var filterErr *pagination.FilterError
+	if errors.As(err, &filterErr) {
+		return ValidationFailed(ctx, err)
+	}
+
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		status := coder.StatusCode()
+		switch {
+		case status < 400 || status > 599:
+			// A success or unset code must not turn an error into success:true
+			return InternalError(ctx)
+		case status >= 500:
+			return WithMessage(ctx, strings.ToLower(http.StatusText(status)), status)
+		}
+		// Only the domain error's own text; wrapping context stays server-side
+		return WithMessage(ctx, coder.(error).Error(), status)
+	}
+
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		return ValidationFailed(ctx, err)
+	}
+
+	errorMappingsMu.RLock()
+	defer errorMappingsMu.RUnlock()
+
+	for _, m := range errorMappings {
+		matched, ok := m.match(err)
+		if !ok {
+			continue
+		}
+		message := m.message
+		if message == "" {
+			message = matched.Error()
+		}
+		return WithMessage(ctx, message, m.status)
+	}
+
+	return InternalError(ctx)
+}
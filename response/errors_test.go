@@ -0,0 +1,98 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/Jkenyut/nvx-go-helper/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTestNotFound = errors.New("row not found")
+	errTestTaken    = errors.New("email already taken")
+)
+
+type testBalanceError struct{ status int }
+
+func (e testBalanceError) Error() string   { return "insufficient balance" }
+func (e testBalanceError) StatusCode() int { return e.status }
+
+type testTimeoutError struct{ op string }
+
+func (e *testTimeoutError) Error() string { return e.op + " timed out" }
+
+func init() {
+	RegisterError(errTestNotFound, 404, "resource not found")
+	RegisterError(errTestTaken, 409, "")
+	RegisterErrorType[*testTimeoutError](504, "upstream timeout")
+}
+
+func TestFromError(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{"nil", nil, 200, "success"},
+		{"sentinel", errTestNotFound, 404, "resource not found"},
+		{"wrapped sentinel", fmt.Errorf("find user: %w", errTestNotFound), 404, "resource not found"},
+		{"sentinel without message", errTestTaken, 409, "email already taken"},
+		{"error type", fmt.Errorf("call: %w", &testTimeoutError{op: "payment"}), 504, "upstream timeout"},
+		{"wrapped sentinel without message", fmt.Errorf("repo.CreateUser(id=42): %w", errTestTaken), 409, "email already taken"},
+		{"status coder 4xx", fmt.Errorf("repo.Pay(id=42): db timeout: %w", testBalanceError{status: 422}), 422, "insufficient balance"},
+		{"status coder 5xx hides details", testBalanceError{status: 503}, 503, "service unavailable"},
+		{"status coder success code", testBalanceError{status: 200}, 500, "internal server error"},
+		{"status coder unset code", testBalanceError{status: 0}, 500, "internal server error"},
+		{"status coder out of range", testBalanceError{status: 600}, 500, "internal server error"},
+		{"invalid cursor", &pagination.CursorError{Reason: "malformed token"}, 400, "invalid cursor: malformed token"},
+		{"unknown", errors.New("connection refused"), 500, "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := FromError(ctx, tt.err)
+			assert.Equal(t, tt.status, resp.Meta.StatusCode)
+			assert.Equal(t, tt.message, resp.Meta.Message)
+			assert.Equal(t, tt.err == nil, resp.Meta.Success)
+		})
+	}
+}
+
+func TestFromError_ValidationErrors(t *testing.T) {
+	err := validator.Struct(testCreateUser{Email: "budi@example.com", Role: "user", Address: testAddress{City: "Jakarta"}})
+
+	resp := FromError(context.Background(), fmt.Errorf("create user: %w", err))
+
+	assert.Equal(t, 422, resp.Meta.StatusCode)
+	assert.Len(t, resp.Errors, 1)
+}
+
+func TestFromError_FilterError(t *testing.T) {
+	f := pagination.Filter{Fields: map[string]pagination.FilterField{
+		"amount": {Column: "amount", Type: pagination.FilterInt, Ops: []pagination.FilterOp{pagination.OpGte}},
+	}}
+	_, err := f.Parse(url.Values{"filter[amount][gte]": {"abc"}, "filter[secret]": {"x"}})
+
+	resp := FromError(context.Background(), fmt.Errorf("list orders: %w", err))
+
+	assert.Equal(t, 422, resp.Meta.StatusCode)
+	assert.Equal(t, "validation failed", resp.Meta.Message)
+	assert.Equal(t, []FieldError{
+		{Field: "filter[amount][gte]", Rule: "type", Param: "integer", Message: "filter[amount][gte] must be a valid integer"},
+		{Field: "filter[secret]", Rule: "unknown", Message: "secret cannot be filtered"},
+	}, resp.Errors)
+}
+
+func TestRegisterError_Panics(t *testing.T) {
+	assert.Panics(t, func() { RegisterError(nil, 404, "not found") })
+	assert.Panics(t, func() { RegisterError(errors.New("x"), 302, "redirect") })
+	assert.Panics(t, func() { RegisterErrorType[*testTimeoutError](0, "") })
+}
@@ -0,0 +1,199 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// WithETag adds a strong ETag computed over the encoded "data" of successful
+// responses. GET and HEAD requests whose If-None-Match matches receive
+// 304 Not Modified without a body.
+//
+// Example:
+//
+//	response.Render(w, r, response.OK(ctx, "catalogue", items), response.WithETag())
+func WithETag() Option {
+	return func(c *renderConfig) { c.etag = true }
+}
+
+// WithLastModified sets the Last-Modified header of successful responses.
+// GET and HEAD requests whose If-Modified-Since is not older than t receive
+// 304 Not Modified (If-None-Match takes precedence when both are sent).
+func WithLastModified(t time.Time) Option {
+	return func(c *renderConfig) { c.lastModified = t }
+}
+
+// ETag returns the strong ETag (quoted, per RFC 9110) of data
+// as it is rendered in the "data" member of the JSON representation.
+//
+// Example:
+//
+//	tag, _ := response.ETag(product) // "\"hWbTn0b3...\""
+func ETag(data any) (string, error) {
+	return etagFor(data, defaultEncoder().mediaType)
+}
+
+// etagFor returns the strong ETag of data rendered as mediaType.
+// Each representation (JSON, XML, ...) gets its own tag, as RFC 9110 §8.8.3
+// requires of strong validators.
+func etagFor(data any, mediaType string) (string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(mediaType))
+	h.Write([]byte{0})
+	h.Write(b)
+	return `"` + base64.RawURLEncoding.EncodeToString(h.Sum(nil)) + `"`, nil
+}
+
+// CheckIfMatch evaluates the If-Match header of a write request (PUT, PATCH,
+// DELETE, ...) against the current representation of the target resource,
+// in the media type the request accepts. Pass nil (or a nil pointer, map or
+// slice) as current when the resource does not exist.
+// It returns ok=false together with a 412 response when the precondition fails.
+//
+// Example:
+//
+//	current, _ := repo.FindProduct(ctx, id)
+//	if resp, ok := response.CheckIfMatch(r, current); !ok {
+//	    return response.Render(w, r, resp)
+//	}
+func CheckIfMatch(req *http.Request, current any) (Response, bool) {
+	header := req.Header.Get("If-Match")
+	if header == "" {
+		return Response{}, true
+	}
+
+	fail := PreconditionFailed(req.Context(), "resource has been modified")
+	if isNilValue(current) {
+		return fail, false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return Response{}, true
+	}
+
+	enc, ok := negotiate(req)
+	if !ok {
+		enc = defaultEncoder()
+	}
+	tag, err := etagFor(current, enc.mediaType)
+	if err != nil {
+		return InternalError(req.Context()), false
+	}
+	// If-Match uses the strong comparison: weak tags never match.
+	// Tags of compressed representations (see CompressMiddleware) are accepted.
+	for _, candidate := range splitETags(header) {
+		if !strings.HasPrefix(candidate, "W/") && stripETagCoding(candidate) == tag {
+			return Response{}, true
+		}
+	}
+	return fail, false
+}
+
+// applyCaching sets ETag / Last-Modified headers on w and reports whether the
+// request's conditional headers allow answering with 304 Not Modified.
+// mediaType is the negotiated representation the ETag is computed for.
+func applyCaching(w http.ResponseWriter, req *http.Request, resp Response, cfg renderConfig, mediaType string) (notModified bool, err error) {
+	if !resp.Meta.Success {
+		return false, nil
+	}
+
+	var tag string
+	if cfg.etag {
+		if tag, err = etagFor(resp.Data, mediaType); err != nil {
+			return false, err
+		}
+		w.Header().Set("ETag", tag)
+	}
+	if !cfg.lastModified.IsZero() {
+		w.Header().Set("Last-Modified", cfg.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if req == nil || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return false, nil
+	}
+	if resp.Meta.StatusCode != http.StatusOK {
+		return false, nil
+	}
+
+	// RFC 9110 §13.2.2: If-None-Match takes precedence over If-Modified-Since
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		return tag != "" && etagMatchesWeak(inm, tag), nil
+	}
+	if ims := req.Header.Get("If-Modified-Since"); ims != "" && !cfg.lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false, nil
+		}
+		// HTTP dates have second precision
+		return !cfg.lastModified.Truncate(time.Second).After(since), nil
+	}
+	return false, nil
+}
+
+// isNilValue reports whether v is nil or a typed nil (pointer, map, slice,
+// interface, ...) wrapped in an interface.
+func isNilValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// etagMatchesWeak reports whether header (If-None-Match) matches tag
+// using the weak comparison.
+func etagMatchesWeak(header, tag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	tag = stripETagCoding(strings.TrimPrefix(tag, "W/"))
+	for _, candidate := range splitETags(header) {
+		if stripETagCoding(strings.TrimPrefix(candidate, "W/")) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagWithCoding returns the tag of the representation compressed with
+// coding: "\"abc\"" → "\"abc-gzip\"" (a W/ prefix is kept).
+func etagWithCoding(tag, coding string) string {
+	if len(tag) < 2 || !strings.HasSuffix(tag, `"`) {
+		return tag
+	}
+	return tag[:len(tag)-1] + "-" + coding + `"`
+}
+
+// stripETagCoding removes a coding suffix added by etagWithCoding.
+func stripETagCoding(tag string) string {
+	for coding := range compressorPools {
+		if base, ok := strings.CutSuffix(tag, "-"+coding+`"`); ok {
+			return base + `"`
+		}
+	}
+	return tag
+}
+
+// splitETags splits a comma-separated list of entity tags.
+func splitETags(header string) []string {
+	parts := strings.Split(header, ",")
+	tags := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			tags = append(tags, p)
+		}
+	}
+	return tags
+}
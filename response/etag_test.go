@@ -0,0 +1,206 @@
+package response
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testProduct struct {
+	ID    int `json:"id"`
+	Price int `json:"price"`
+}
+
+func TestETag(t *testing.T) {
+	a, err := ETag(testProduct{ID: 1, Price: 100})
+	require.NoError(t, err)
+	b, _ := ETag(testProduct{ID: 1, Price: 100})
+	c, _ := ETag(testProduct{ID: 1, Price: 200})
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Regexp(t, `^"[A-Za-z0-9_-]{43}"$`, a)
+
+	_, err = ETag(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestRender_WithETag(t *testing.T) {
+	product := testProduct{ID: 1, Price: 100}
+	tag, _ := ETag(product)
+
+	// First request: full body and ETag
+	req := httptest.NewRequest("GET", "/products/1", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, Render(rec, req, OK(req.Context(), "product", product), WithETag()))
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, tag, rec.Header().Get("ETag"))
+	assert.NotEmpty(t, rec.Body.String())
+
+	// Revalidation: 304 without body
+	req = httptest.NewRequest("GET", "/products/1", nil)
+	req.Header.Set("If-None-Match", `"other", W/`+tag)
+	rec = httptest.NewRecorder()
+	require.NoError(t, Render(rec, req, OK(req.Context(), "product", product), WithETag()))
+	assert.Equal(t, 304, rec.Code)
+	assert.Equal(t, tag, rec.Header().Get("ETag"))
+	assert.NotEmpty(t, rec.Header().Get(HeaderRequestID))
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Body.String())
+
+	// Changed resource: full body again
+	req = httptest.NewRequest("GET", "/products/1", nil)
+	req.Header.Set("If-None-Match", tag)
+	rec = httptest.NewRecorder()
+	require.NoError(t, Render(rec, req, OK(req.Context(), "product", testProduct{ID: 1, Price: 200}), WithETag()))
+	assert.Equal(t, 200, rec.Code)
+}
+
+func TestRender_WithETagSkipsErrorsAndWrites(t *testing.T) {
+	req := httptest.NewRequest("GET", "/products/1", nil)
+	req.Header.Set("If-None-Match", "*")
+	rec := httptest.NewRecorder()
+	require.NoError(t, Render(rec, req, NotFound(req.Context(), "product not found"), WithETag()))
+	assert.Equal(t, 404, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest("POST", "/products", nil)
+	req.Header.Set("If-None-Match", "*")
+	rec = httptest.NewRecorder()
+	require.NoError(t, Render(rec, req, Created(req.Context(), "created", testProduct{ID: 2}), WithETag()))
+	assert.Equal(t, 201, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+}
+
+func TestRender_WithLastModified(t *testing.T) {
+	modified := time.Date(2025, 6, 1, 10, 30, 15, 500, time.UTC)
+
+	tests := []struct {
+		name   string
+		ims    string
+		status int
+	}{
+		{"not modified since", "Sun, 01 Jun 2025 10:30:15 GMT", 304},
+		{"modified since", "Sun, 01 Jun 2025 10:30:14 GMT", 200},
+		{"invalid date", "yesterday", 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/catalogue", nil)
+			req.Header.Set("If-Modified-Since", tt.ims)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, Render(rec, req, OK(req.Context(), "catalogue", []int{1}), WithLastModified(modified)))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "Sun, 01 Jun 2025 10:30:15 GMT", rec.Header().Get("Last-Modified"))
+		})
+	}
+}
+
+func TestRender_IfNoneMatchTakesPrecedence(t *testing.T) {
+	modified := time.Date(2025, 6, 1, 10, 30, 15, 0, time.UTC)
+	req := httptest.NewRequest("GET", "/catalogue", nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	req.Header.Set("If-Modified-Since", "Sun, 01 Jun 2025 10:30:15 GMT")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(req.Context(), "catalogue", []int{1}), WithETag(), WithLastModified(modified)))
+
+	assert.Equal(t, 200, rec.Code)
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	current := testProduct{ID: 1, Price: 100}
+	tag, _ := ETag(current)
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		current any
+		ok      bool
+	}{
+		{"no header", "", current, true},
+		{"matching tag", `"x", ` + tag, current, true},
+		{"stale tag", `"stale"`, current, false},
+		{"weak tag never matches", "W/" + tag, current, false},
+		{"compressed representation tag", strings.TrimSuffix(tag, `"`) + `-gzip"`, current, true},
+		{"unknown suffix", strings.TrimSuffix(tag, `"`) + `-br"`, current, false},
+		{"wildcard on existing", "*", current, true},
+		{"wildcard on missing", "*", nil, false},
+		{"wildcard on typed nil pointer", "*", (*testProduct)(nil), false},
+		{"wildcard on nil map", "*", map[string]any(nil), false},
+		{"wildcard on empty struct", "*", struct{}{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/products/1", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			resp, ok := CheckIfMatch(req, tt.current)
+
+			assert.Equal(t, tt.ok, ok)
+			if !ok {
+				assert.Equal(t, 412, resp.Meta.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRender_ETagPerMediaType(t *testing.T) {
+	product := testProduct{ID: 1, Price: 100}
+	render := func(accept string) string {
+		req := httptest.NewRequest("GET", "/products/1", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, Render(rec, req, OK(req.Context(), "product", product), WithETag()))
+		return rec.Header().Get("ETag")
+	}
+
+	jsonTag, xmlTag := render(""), render("application/xml")
+	tag, _ := ETag(product)
+
+	assert.Equal(t, tag, jsonTag)
+	assert.Equal(t, jsonTag, render("application/json"))
+	assert.NotEqual(t, jsonTag, xmlTag)
+	assert.NotEqual(t, xmlTag, render("text/xml"))
+}
+
+func TestCheckIfMatch_NegotiatedRepresentation(t *testing.T) {
+	current := testProduct{ID: 1, Price: 100}
+	get := httptest.NewRequest("GET", "/products/1", nil)
+	get.Header.Set("Accept", "application/xml")
+	rec := httptest.NewRecorder()
+	require.NoError(t, Render(rec, get, OK(get.Context(), "product", current), WithETag()))
+
+	put := httptest.NewRequest("PUT", "/products/1", nil)
+	put.Header.Set("Accept", "application/xml")
+	put.Header.Set("If-Match", rec.Header().Get("ETag"))
+	_, ok := CheckIfMatch(put, current)
+	assert.True(t, ok)
+
+	// The XML tag does not validate the JSON representation
+	put.Header.Del("Accept")
+	_, ok = CheckIfMatch(put, current)
+	assert.False(t, ok)
+}
+
+func TestCheckIfMatch_UnencodableCurrent(t *testing.T) {
+	req := httptest.NewRequest("PUT", "/products/1", nil)
+	req.Header.Set("If-Match", `"x"`)
+
+	resp, ok := CheckIfMatch(req, make(chan int))
+
+	assert.False(t, ok)
+	assert.Equal(t, 500, resp.Meta.StatusCode)
+}
@@ -0,0 +1,192 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// FieldsParam is the query parameter read by WithFields.
+const FieldsParam = "fields"
+
+// WithFields trims the "data" of successful responses to the dotted paths
+// listed in the ?fields= query parameter (e.g. ?fields=id,name,address.city).
+// In strict mode, requesting a field that does not exist yields a
+// 400 Bad Request listing the unknown fields in "errors".
+//
+// Example:
+//
+//	response.Render(w, r, response.OK(ctx, "users", users), response.WithFields(true))
+func WithFields(strict bool) Option {
+	return func(c *renderConfig) {
+		c.fields = true
+		c.strictFields = strict
+	}
+}
+
+// ParseFields splits a ?fields= value into trimmed, non-empty paths.
+func ParseFields(raw string) []string {
+	var fields []string
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// SelectFields returns the JSON representation of data pruned to the given
+// dotted paths. Structs, maps and slices (at any depth) are supported; for
+// slices the paths apply to every element.
+// It also returns the requested paths that exist in none of the objects.
+//
+// Example:
+//
+//	trimmed, unknown, err := response.SelectFields(users, []string{"id", "address.city"})
+func SelectFields(data any, fields []string) (any, []string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// UseNumber keeps large integers (IDs, amounts) exact
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, nil, err
+	}
+
+	root := newFieldTree(fields)
+	pruned := root.apply(generic)
+	return pruned, root.unknown(), nil
+}
+
+// fieldNode is one segment of the requested field paths.
+type fieldNode struct {
+	path     string                // full dotted path up to this node
+	leaf     bool                  // keep the whole subtree
+	children map[string]*fieldNode // nested selections
+	checked  bool                  // an enclosing object has been visited
+	found    bool                  // the key exists in at least one object
+}
+
+// newFieldTree builds the selection tree for the given paths.
+func newFieldTree(fields []string) *fieldNode {
+	root := &fieldNode{children: map[string]*fieldNode{}}
+	for _, f := range fields {
+		node := root
+		for _, seg := range strings.Split(f, ".") {
+			child, ok := node.children[seg]
+			if !ok {
+				path := seg
+				if node.path != "" {
+					path = node.path + "." + seg
+				}
+				child = &fieldNode{path: path, children: map[string]*fieldNode{}}
+				node.children[seg] = child
+			}
+			node = child
+		}
+		node.leaf = true
+	}
+	return root
+}
+
+// apply prunes v according to the children of n.
+func (n *fieldNode) apply(v any) any {
+	switch val := v.(type) {
+	case []any:
+		out := make([]any, len(val))
+		for i, elem := range val {
+			out[i] = n.apply(elem)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(n.children))
+		for key, child := range n.children {
+			child.checked = true
+			fv, ok := val[key]
+			if !ok {
+				continue
+			}
+			child.found = true
+			if child.leaf {
+				out[key] = fv
+			} else {
+				out[key] = child.apply(fv)
+			}
+		}
+		return out
+	case nil:
+		// A null says nothing about the fields the value would have
+		return v
+	default:
+		// Scalars cannot be selected into: their nested paths do not exist
+		for _, child := range n.children {
+			child.checked = true
+		}
+		return v
+	}
+}
+
+// unknown returns the sorted requested paths proven not to exist.
+func (n *fieldNode) unknown() []string {
+	var out []string
+	for _, child := range n.children {
+		switch {
+		case child.checked && !child.found:
+			out = append(out, child.leafPaths()...)
+		case child.found && !child.leaf:
+			out = append(out, child.unknown()...)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// leafPaths returns every requested path at or below n.
+func (n *fieldNode) leafPaths() []string {
+	var out []string
+	if n.leaf {
+		out = append(out, n.path)
+	}
+	for _, child := range n.children {
+		out = append(out, child.leafPaths()...)
+	}
+	return out
+}
+
+// applyFields trims resp.Data according to the request's ?fields= parameter.
+func applyFields(req *http.Request, resp Response, strict bool) (Response, error) {
+	if req == nil || req.URL == nil || !resp.Meta.Success || resp.Data == nil {
+		return resp, nil
+	}
+	fields := ParseFields(req.URL.Query().Get(FieldsParam))
+	if len(fields) == 0 {
+		return resp, nil
+	}
+
+	pruned, unknown, err := SelectFields(resp.Data, fields)
+	if err != nil {
+		return resp, err
+	}
+
+	if strict && len(unknown) > 0 {
+		bad := BadRequest(req.Context(), "unknown fields requested")
+		bad.Meta.RequestID = resp.Meta.RequestID
+		for _, f := range unknown {
+			bad.Errors = append(bad.Errors, FieldError{
+				Field:   f,
+				Rule:    "unknown",
+				Message: f + " is not a known field",
+			})
+		}
+		return bad, nil
+	}
+
+	resp.Data = pruned
+	return resp, nil
+}
@@ -0,0 +1,133 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFieldsAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type testFieldsUser struct {
+	ID      int64              `json:"id"`
+	Name    string             `json:"name"`
+	Email   string             `json:"email"`
+	Address testFieldsAddress  `json:"address"`
+	Tags    []string           `json:"tags"`
+	Manager *testFieldsAddress `json:"manager"`
+}
+
+var testFieldsBudi = testFieldsUser{
+	ID:      9007199254740993, // not representable as float64
+	Name:    "Budi",
+	Email:   "budi@example.com",
+	Address: testFieldsAddress{City: "Jakarta", Street: "Jl. Sudirman"},
+	Tags:    []string{"vip"},
+}
+
+func toJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestParseFields(t *testing.T) {
+	assert.Equal(t, []string{"id", "address.city"}, ParseFields(" id, ,address.city,"))
+	assert.Nil(t, ParseFields(""))
+}
+
+func TestSelectFields_Struct(t *testing.T) {
+	got, unknown, err := SelectFields(testFieldsBudi, []string{"id", "name", "address.city"})
+
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+	assert.JSONEq(t, `{"id":9007199254740993,"name":"Budi","address":{"city":"Jakarta"}}`, toJSON(t, got))
+}
+
+func TestSelectFields_SliceAndMap(t *testing.T) {
+	data := map[string]any{
+		"users": []testFieldsUser{testFieldsBudi, {ID: 2, Name: "Sari"}},
+		"total": 2,
+	}
+
+	got, unknown, err := SelectFields(data, []string{"users.name", "users.address", "total"})
+
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+	assert.JSONEq(t, `{
+		"users": [
+			{"name":"Budi","address":{"city":"Jakarta","street":"Jl. Sudirman"}},
+			{"name":"Sari","address":{"city":"","street":""}}
+		],
+		"total": 2
+	}`, toJSON(t, got))
+}
+
+func TestSelectFields_Unknown(t *testing.T) {
+	_, unknown, err := SelectFields(
+		[]testFieldsUser{testFieldsBudi},
+		[]string{"id", "password", "address.zip", "name.first", "tags.label", "manager.city", "nope.a", "nope.b"},
+	)
+
+	require.NoError(t, err)
+	// name.first and tags.label select into scalars, so they do not exist;
+	// manager.city is not reported: manager is null, so there is no evidence
+	assert.Equal(t, []string{"address.zip", "name.first", "nope.a", "nope.b", "password", "tags.label"}, unknown)
+}
+
+func TestSelectFields_EncodingError(t *testing.T) {
+	_, _, err := SelectFields(make(chan int), []string{"id"})
+
+	assert.Error(t, err)
+}
+
+func TestRender_WithFields(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/1?fields=id,address.city,password", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(req.Context(), "user", testFieldsBudi), WithFields(false)))
+
+	assert.Equal(t, 200, rec.Code)
+	var got struct {
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.JSONEq(t, `{"id":9007199254740993,"address":{"city":"Jakarta"}}`, string(got.Data))
+}
+
+func TestRender_WithFieldsStrict(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/1?fields=id,password,address.zip,name.first", nil)
+	ctx := activity.WithRequestID(req.Context(), "req-fields-1")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(ctx, "user", testFieldsBudi), WithFields(true)))
+
+	assert.Equal(t, 400, rec.Code)
+	var got Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, "unknown fields requested", got.Meta.Message)
+	assert.Equal(t, "req-fields-1", got.Meta.RequestID)
+	assert.Nil(t, got.Data)
+	assert.Equal(t, []FieldError{
+		{Field: "address.zip", Rule: "unknown", Message: "address.zip is not a known field"},
+		{Field: "name.first", Rule: "unknown", Message: "name.first is not a known field"},
+		{Field: "password", Rule: "unknown", Message: "password is not a known field"},
+	}, got.Errors)
+}
+
+func TestRender_WithFieldsIgnoredWithoutParam(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(req.Context(), "user", testFieldsBudi), WithFields(true)))
+
+	assert.Contains(t, rec.Body.String(), `"email":"budi@example.com"`)
+}
@@ -0,0 +1,319 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+)
+
+// Supported locales of the built-in message bundles.
+const (
+	LocaleEN = "en" // English
+	LocaleID = "id" // Bahasa Indonesia
+)
+
+// Built-in message IDs, available in every bundled locale.
+const (
+	MsgSuccess             = "success"
+	MsgCreated             = "created"
+	MsgNoContent           = "no_content"
+	MsgBadRequest          = "bad_request"
+	MsgUnauthorized        = "unauthorized"
+	MsgForbidden           = "forbidden"
+	MsgNotFound            = "not_found"
+	MsgConflict            = "conflict"
+	MsgValidationFailed    = "validation_failed"
+	MsgTooManyRequests     = "too_many_requests"
+	MsgInternalServerError = "internal_server_error"
+)
+
+// messages holds the catalog: locale → message ID → template.
+var (
+	messagesMu    sync.RWMutex
+	defaultLocale = LocaleEN
+	messages      = map[string]map[string]string{
+		LocaleEN: {
+			MsgSuccess:             "success",
+			MsgCreated:             "created successfully",
+			MsgNoContent:           "no content",
+			MsgBadRequest:          "bad request",
+			MsgUnauthorized:        "unauthorized",
+			MsgForbidden:           "access denied",
+			MsgNotFound:            "resource not found",
+			MsgConflict:            "resource already exists",
+			MsgValidationFailed:    "validation failed",
+			MsgTooManyRequests:     "too many requests",
+			MsgInternalServerError: "internal server error",
+		},
+		LocaleID: {
+			MsgSuccess:             "berhasil",
+			MsgCreated:             "berhasil dibuat",
+			MsgNoContent:           "tidak ada konten",
+			MsgBadRequest:          "permintaan tidak valid",
+			MsgUnauthorized:        "tidak terautentikasi",
+			MsgForbidden:           "akses ditolak",
+			MsgNotFound:            "data tidak ditemukan",
+			MsgConflict:            "data sudah ada",
+			MsgValidationFailed:    "validasi gagal",
+			MsgTooManyRequests:     "terlalu banyak permintaan",
+			MsgInternalServerError: "terjadi kesalahan pada server",
+		},
+	}
+)
+
+// RegisterMessages adds (or overrides) message templates for a locale.
+// Templates use fmt verbs for their arguments. Call it at startup.
+//
+// Example:
+//
+//	response.RegisterMessages(response.LocaleEN, map[string]string{"user.not_found": "user %s not found"})
+//	response.RegisterMessages(response.LocaleID, map[string]string{"user.not_found": "pengguna %s tidak ditemukan"})
+func RegisterMessages(locale string, bundle map[string]string) {
+	locale = normalizeLocale(locale)
+
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+
+	if messages[locale] == nil {
+		messages[locale] = make(map[string]string, len(bundle))
+	}
+	for id, tmpl := range bundle {
+		messages[locale][id] = tmpl
+	}
+}
+
+// SetDefaultLocale sets the locale used when the context carries none
+// and as the fallback for missing translations (default "en").
+func SetDefaultLocale(locale string) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	defaultLocale = normalizeLocale(locale)
+}
+
+// Translate returns the message for id in the context locale, formatted with args.
+// It falls back to the default locale, then to the ID itself (never formatted).
+// Templates are always formatted, so "%%" renders as "%". A template whose
+// verbs do not take exactly len(args) arguments is skipped like a missing one,
+// so clients never see "%!(EXTRA ...)", "%!d(MISSING)" or a raw "%s".
+//
+// Example:
+//
+//	msg := response.Translate(ctx, "user.not_found", userID)
+func Translate(ctx context.Context, id string, args ...any) string {
+	locale, _ := activity.GetLocale(ctx)
+	locale = normalizeLocale(locale)
+
+	usable := func(tmpl string, ok bool) bool {
+		return ok && formatArity(tmpl) == len(args)
+	}
+
+	messagesMu.RLock()
+	tmpl, ok := messages[locale][id]
+	if !usable(tmpl, ok) {
+		tmpl, ok = messages[defaultLocale][id]
+	}
+	messagesMu.RUnlock()
+
+	if !usable(tmpl, ok) {
+		return id
+	}
+	return fmt.Sprintf(tmpl, args...)
+}
+
+// formatArity returns the number of arguments the fmt verbs of tmpl consume,
+// honoring "%%", "*" widths and explicit argument indexes ("%[2]s").
+// It returns -1 for a malformed template, such as one ending in "%".
+func formatArity(tmpl string) int {
+	next, arity := 0, 0
+	consume := func() {
+		next++
+		arity = max(arity, next)
+	}
+
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] != '%' {
+			continue
+		}
+		for i++; i < len(tmpl); i++ {
+			c := tmpl[i]
+			if c == '[' {
+				end := strings.IndexByte(tmpl[i:], ']')
+				if end < 0 {
+					return -1
+				}
+				n, err := strconv.Atoi(tmpl[i+1 : i+end])
+				if err != nil || n < 1 {
+					return -1
+				}
+				next = n - 1
+				i += end
+				continue
+			}
+			if c == '*' {
+				consume()
+				continue
+			}
+			if strings.IndexByte("+-# 0123456789.", c) >= 0 {
+				continue
+			}
+			if c != '%' {
+				consume()
+			}
+			break
+		}
+		if i >= len(tmpl) {
+			// "%" without a verb
+			return -1
+		}
+	}
+	return arity
+}
+
+// DetectLocale picks the best registered locale from an Accept-Language header,
+// honoring q-values and matching on the primary language subtag ("id-ID" → "id").
+// It returns the default locale when nothing matches.
+//
+// Example:
+//
+//	DetectLocale("id-ID,id;q=0.9,en;q=0.8") // "id"
+func DetectLocale(acceptLanguage string) string {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{locale: normalizeLocale(tag), q: q})
+		}
+	}
+	// Highest q first; equal q keeps the header order
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	messagesMu.RLock()
+	defer messagesMu.RUnlock()
+
+	for _, c := range candidates {
+		if _, ok := messages[c.locale]; ok {
+			return c.locale
+		}
+	}
+	return defaultLocale
+}
+
+// LocaleMiddleware stores the locale detected from Accept-Language in the
+// request context, unless an earlier middleware already set one.
+//
+// Example:
+//
+//	handler = response.LocaleMiddleware(mux)
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := activity.GetLocale(r.Context()); !ok {
+			ctx := activity.WithLocale(r.Context(), DetectLocale(r.Header.Get("Accept-Language")))
+			r = r.WithContext(ctx)
+		}
+		addVary(w.Header(), "Accept-Language")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// normalizeLocale reduces a language tag to its lowercase primary subtag.
+func normalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// === LOCALIZED CONSTRUCTORS ===
+
+// Localized builds a response whose message is translated from msgID
+// (see Translate). Success is derived from the status code.
+func Localized(ctx context.Context, status int, msgID string, data any, args ...any) Response {
+	return WithMessageData(ctx, Translate(ctx, msgID, args...), status, data)
+}
+
+// OKT sends a 200 OK response with a translated message.
+//
+// Example:
+//
+//	return response.OKT(ctx, "user.found", user, user.Name)
+func OKT(ctx context.Context, msgID string, data any, args ...any) Response {
+	return Localized(ctx, http.StatusOK, msgID, data, args...)
+}
+
+// CreatedT sends a 201 Created response with a translated message.
+func CreatedT(ctx context.Context, msgID string, data any, args ...any) Response {
+	return Localized(ctx, http.StatusCreated, msgID, data, args...)
+}
+
+// AcceptedT sends a 202 Accepted response with a translated message.
+func AcceptedT(ctx context.Context, msgID string, data any, args ...any) Response {
+	return Localized(ctx, http.StatusAccepted, msgID, data, args...)
+}
+
+// BadRequestT sends a 400 Bad Request response with a translated message.
+func BadRequestT(ctx context.Context, msgID string, args ...any) Response {
+	return Localized(ctx, http.StatusBadRequest, msgID, nil, args...)
+}
+
+// UnauthorizedT sends a 401 Unauthorized response with a translated message.
+func UnauthorizedT(ctx context.Context, msgID string, args ...any) Response {
+	return Localized(ctx, http.StatusUnauthorized, msgID, nil, args...)
+}
+
+// ForbiddenT sends a 403 Forbidden response with a translated message.
+func ForbiddenT(ctx context.Context, msgID string, args ...any) Response {
+	return Localized(ctx, http.StatusForbidden, msgID, nil, args...)
+}
+
+// NotFoundT sends a 404 Not Found response with a translated message.
+//
+// Example:
+//
+//	return response.NotFoundT(ctx, "user.not_found", id)
+func NotFoundT(ctx context.Context, msgID string, args ...any) Response {
+	return Localized(ctx, http.StatusNotFound, msgID, nil, args...)
+}
+
+// ConflictT sends a 409 Conflict response with a translated message.
+func ConflictT(ctx context.Context, msgID string, args ...any) Response {
+	return Localized(ctx, http.StatusConflict, msgID, nil, args...)
+}
+
+// UnprocessableEntityT sends a 422 Unprocessable Entity response with a translated message.
+func UnprocessableEntityT(ctx context.Context, msgID string, args ...any) Response {
+	return Localized(ctx, http.StatusUnprocessableEntity, msgID, nil, args...)
+}
+
+// TooManyRequestsT sends a 429 Too Many Requests response with a translated message.
+func TooManyRequestsT(ctx context.Context, msgID string, args ...any) Response {
+	return Localized(ctx, http.StatusTooManyRequests, msgID, nil, args...)
+}
+
+// InternalErrorT sends a 500 Internal Server Error response with the translated
+// built-in message.
+func InternalErrorT(ctx context.Context) Response {
+	return Localized(ctx, http.StatusInternalServerError, MsgInternalServerError, nil)
+}
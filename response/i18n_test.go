@@ -0,0 +1,154 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	RegisterMessages(LocaleEN, map[string]string{
+		"test.user.not_found": "user %s not found",
+		"test.only_english":   "only in english",
+		"test.transfer":       "sent %[2]d to %[1]s",
+		"test.discount":       "discount 100%%",
+		"test.needs_arg":      "user %s not found",
+	})
+	RegisterMessages("id-ID", map[string]string{
+		"test.user.not_found": "pengguna %s tidak ditemukan",
+		"test.transfer":       "transfer berhasil", // arity drifted from the default locale
+	})
+}
+
+func TestTranslate(t *testing.T) {
+	en := context.Background()
+	id := activity.WithLocale(context.Background(), "id")
+
+	assert.Equal(t, "user 42 not found", Translate(en, "test.user.not_found", "42"))
+	assert.Equal(t, "pengguna 42 tidak ditemukan", Translate(id, "test.user.not_found", "42"))
+	assert.Equal(t, "only in english", Translate(id, "test.only_english")) // default locale fallback
+	assert.Equal(t, "test.missing", Translate(id, "test.missing"))         // ID fallback
+	assert.Equal(t, "validasi gagal", Translate(id, MsgValidationFailed))  // built-in bundle
+	assert.Equal(t, "berhasil", Translate(activity.WithLocale(en, "ID_id"), MsgSuccess))
+}
+
+func TestTranslate_ArgsMismatch(t *testing.T) {
+	en := context.Background()
+	id := activity.WithLocale(context.Background(), "id")
+
+	assert.Equal(t, "test.missing.id", Translate(en, "test.missing.id", 42))
+	assert.Equal(t, "sent 5 to budi", Translate(en, "test.transfer", "budi", 5))
+	assert.Equal(t, "sent 5 to budi", Translate(id, "test.transfer", "budi", 5)) // default locale fallback
+	assert.Equal(t, "test.user.not_found", Translate(en, "test.user.not_found", "42", "extra"))
+	assert.Equal(t, "test.needs_arg", Translate(en, "test.needs_arg")) // never a raw %s
+}
+
+func TestTranslate_FormatsWithoutArgs(t *testing.T) {
+	assert.Equal(t, "discount 100%", Translate(context.Background(), "test.discount"))
+}
+
+func TestFormatArity(t *testing.T) {
+	tests := map[string]int{
+		"plain":             0,
+		"100%% done":        0,
+		"user %s not found": 1,
+		"%-10s %+.2f %x":    3,
+		"%*d":               2,
+		"%[2]d to %[1]s":    2,
+		"%[1]s and %[1]q":   1,
+		"%[3]v then %v":     4,
+		"trailing %":        -1,
+		"%[x]d":             -1,
+		"%[2":               -1,
+		"%v%%%v":            2,
+	}
+	for tmpl, want := range tests {
+		assert.Equal(t, want, formatArity(tmpl), tmpl)
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"id-ID,id;q=0.9,en-US;q=0.8,en;q=0.7", LocaleID},
+		{"en-US,en;q=0.9,id;q=0.8", LocaleEN},
+		{"fr-FR,fr;q=0.9,id;q=0.5", LocaleID},
+		{"en;q=0.5, id;q=0.9", LocaleID},
+		{"id;q=0, en;q=0.1", LocaleEN},
+		{"fr", LocaleEN},
+		{"", LocaleEN},
+		{"id;q=abc", LocaleEN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectLocale(tt.header))
+		})
+	}
+}
+
+func TestSetDefaultLocale(t *testing.T) {
+	SetDefaultLocale(LocaleID)
+	defer SetDefaultLocale(LocaleEN)
+
+	assert.Equal(t, "data tidak ditemukan", Translate(context.Background(), MsgNotFound))
+	assert.Equal(t, LocaleID, DetectLocale("fr"))
+}
+
+func TestLocaleMiddleware(t *testing.T) {
+	var got string
+	handler := LocaleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = activity.GetLocale(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "id-ID,id;q=0.9")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, LocaleID, got)
+	assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+
+	// A locale already in the context (e.g. from the user profile) wins
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "id")
+	req = req.WithContext(activity.WithLocale(req.Context(), LocaleEN))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, LocaleEN, got)
+}
+
+func TestLocalizedConstructors(t *testing.T) {
+	ctx := activity.WithLocale(context.Background(), LocaleID)
+
+	tests := []struct {
+		name    string
+		resp    Response
+		status  int
+		message string
+	}{
+		{"OKT", OKT(ctx, MsgSuccess, "data"), 200, "berhasil"},
+		{"CreatedT", CreatedT(ctx, MsgCreated, nil), 201, "berhasil dibuat"},
+		{"AcceptedT", AcceptedT(ctx, MsgSuccess, nil), 202, "berhasil"},
+		{"BadRequestT", BadRequestT(ctx, MsgBadRequest), 400, "permintaan tidak valid"},
+		{"UnauthorizedT", UnauthorizedT(ctx, MsgUnauthorized), 401, "tidak terautentikasi"},
+		{"ForbiddenT", ForbiddenT(ctx, MsgForbidden), 403, "akses ditolak"},
+		{"NotFoundT", NotFoundT(ctx, "test.user.not_found", "budi"), 404, "pengguna budi tidak ditemukan"},
+		{"ConflictT", ConflictT(ctx, MsgConflict), 409, "data sudah ada"},
+		{"UnprocessableEntityT", UnprocessableEntityT(ctx, MsgValidationFailed), 422, "validasi gagal"},
+		{"TooManyRequestsT", TooManyRequestsT(ctx, MsgTooManyRequests), 429, "terlalu banyak permintaan"},
+		{"InternalErrorT", InternalErrorT(ctx), 500, "terjadi kesalahan pada server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, tt.resp.Meta.StatusCode)
+			assert.Equal(t, tt.message, tt.resp.Meta.Message)
+			assert.Equal(t, tt.status < 300, tt.resp.Meta.Success)
+		})
+	}
+}
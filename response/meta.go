@@ -0,0 +1,129 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+)
+
+// Header names written for the optional meta extensions.
+const (
+	HeaderAPIVersion   = "X-API-Version" // echoes Meta.APIVersion
+	HeaderServerTiming = "Server-Timing" // "app;dur=<latency_ms>"
+	HeaderDeprecation  = "Deprecation"   // RFC 9745, "@<unix seconds>"
+	HeaderSunset       = "Sunset"        // RFC 8594, HTTP-date
+)
+
+// Deprecation describes a deprecated endpoint.
+//
+// Example JSON output:
+//
+//	"deprecation": { "since": "2026-01-01T00:00:00Z", "sunset": "2026-07-01T00:00:00Z", "link": "https://docs.example.com/migrate-v2" }
+type Deprecation struct {
+	Since  time.Time `json:"since,omitzero"`  // when the endpoint was deprecated
+	Sunset time.Time `json:"sunset,omitzero"` // when it stops working
+	Link   string    `json:"link,omitempty"`  // migration guide
+}
+
+// deprecationKey is the context key of the Deprecation notice.
+type deprecationKey struct{}
+
+// ContextWithDeprecation marks every response built from ctx as deprecated.
+func ContextWithDeprecation(ctx context.Context, d Deprecation) context.Context {
+	return context.WithValue(ctx, deprecationKey{}, d)
+}
+
+// DeprecationFromContext retrieves the Deprecation notice from the context.
+func DeprecationFromContext(ctx context.Context) (Deprecation, bool) {
+	d, ok := ctx.Value(deprecationKey{}).(Deprecation)
+	return d, ok
+}
+
+// TimingMiddleware records the request start time in the context, so every
+// response reports meta.server_time and meta.latency_ms (and a Server-Timing
+// header when rendered with Render). An earlier start time is kept.
+//
+// Example:
+//
+//	handler = response.TimingMiddleware(mux)
+func TimingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := activity.GetRequestStart(r.Context()); !ok {
+			r = r.WithContext(activity.WithRequestStart(r.Context(), time.Now()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// VersionMiddleware stores the API version in the context, reported as
+// meta.api_version and the X-API-Version header.
+//
+// Example:
+//
+//	v2 := response.VersionMiddleware("v2")(v2Mux)
+func VersionMiddleware(version string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(activity.WithAPIVersion(r.Context(), version)))
+		})
+	}
+}
+
+// DeprecationMiddleware marks the wrapped handler as deprecated: responses carry
+// meta.deprecation and the Deprecation, Sunset and Link headers.
+//
+// Example:
+//
+//	mux.Handle("/v1/users", response.DeprecationMiddleware(response.Deprecation{
+//	    Since:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+//	    Sunset: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+//	    Link:   "https://docs.example.com/migrate-v2",
+//	})(usersV1))
+func DeprecationMiddleware(d Deprecation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(ContextWithDeprecation(r.Context(), d)))
+		})
+	}
+}
+
+// extendMeta fills the optional meta fields from the context.
+func extendMeta(ctx context.Context, m *Meta) {
+	if start, ok := activity.GetRequestStart(ctx); ok {
+		now := time.Now()
+		m.ServerTime = now.UTC()
+		m.LatencyMS = float64(now.Sub(start).Microseconds()) / 1000
+	}
+	if version, ok := activity.GetAPIVersion(ctx); ok {
+		m.APIVersion = version
+	}
+	if d, ok := DeprecationFromContext(ctx); ok {
+		m.Deprecation = &d
+	}
+}
+
+// writeMetaHeaders mirrors the optional meta fields as standard headers.
+func writeMetaHeaders(h http.Header, m Meta) {
+	if m.APIVersion != "" {
+		h.Set(HeaderAPIVersion, m.APIVersion)
+	}
+	if !m.ServerTime.IsZero() {
+		h.Set(HeaderServerTiming, "app;dur="+strconv.FormatFloat(m.LatencyMS, 'f', -1, 64))
+	}
+	if d := m.Deprecation; d != nil {
+		if !d.Since.IsZero() {
+			h.Set(HeaderDeprecation, "@"+strconv.FormatInt(d.Since.Unix(), 10))
+		}
+		if !d.Sunset.IsZero() {
+			h.Set(HeaderSunset, d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.Link != "" {
+			// Added, not set: a paginated response already carries prev/next links
+			h.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, d.Link))
+		}
+	}
+}
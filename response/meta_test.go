@@ -0,0 +1,97 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMeta_Extensions(t *testing.T) {
+	// Without middleware the field set is unchanged
+	body, err := json.Marshal(OK(context.Background(), "ok", nil).Meta)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "server_time")
+	assert.NotContains(t, string(body), "latency_ms")
+	assert.NotContains(t, string(body), "api_version")
+	assert.NotContains(t, string(body), "deprecation")
+
+	d := Deprecation{Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Link: "https://docs.example.com/v2"}
+	ctx := activity.WithRequestStart(context.Background(), time.Now().Add(-25*time.Millisecond))
+	ctx = activity.WithAPIVersion(ctx, "v1")
+	ctx = ContextWithDeprecation(ctx, d)
+
+	meta := OK(ctx, "ok", nil).Meta
+	assert.WithinDuration(t, time.Now(), meta.ServerTime, time.Second)
+	assert.GreaterOrEqual(t, meta.LatencyMS, 25.0)
+	assert.Equal(t, "v1", meta.APIVersion)
+	require.NotNil(t, meta.Deprecation)
+	assert.Equal(t, d, *meta.Deprecation)
+}
+
+func TestRender_MetaHeaders(t *testing.T) {
+	d := Deprecation{
+		Since:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		Link:   "https://docs.example.com/v2",
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = Render(w, r, OK(r.Context(), "ok", "payload"))
+	})
+	handler = DeprecationMiddleware(d)(handler)
+	handler = VersionMiddleware("v1")(handler)
+	handler = TimingMiddleware(handler)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/users", nil))
+
+	h := rec.Header()
+	assert.Equal(t, "v1", h.Get(HeaderAPIVersion))
+	assert.True(t, strings.HasPrefix(h.Get(HeaderServerTiming), "app;dur="))
+	assert.Equal(t, "@1767225600", h.Get(HeaderDeprecation))
+	assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", h.Get(HeaderSunset))
+	assert.Equal(t, `<https://docs.example.com/v2>; rel="deprecation"`, h.Get("Link"))
+
+	var got Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, "v1", got.Meta.APIVersion)
+	assert.False(t, got.Meta.ServerTime.IsZero())
+	require.NotNil(t, got.Meta.Deprecation)
+	assert.Equal(t, d.Link, got.Meta.Deprecation.Link)
+}
+
+func TestTimingMiddleware_KeepsEarlierStart(t *testing.T) {
+	start := time.Now().Add(-time.Minute)
+
+	var got time.Time
+	handler := TimingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = activity.GetRequestStart(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(activity.WithRequestStart(req.Context(), start)))
+	assert.Equal(t, start, got)
+}
+
+func TestRender_DeprecationLinkKeepsPagination(t *testing.T) {
+	ctx := ContextWithDeprecation(context.Background(), Deprecation{Link: "https://docs.example.com/v2"})
+	req := httptest.NewRequest("GET", "http://api.example.com/users?page=1&limit=10", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, Paginated(ctx, "ok", []int{1}, pagination.New("1", "10", 35))))
+
+	links := rec.Header().Values("Link")
+	require.Len(t, links, 2)
+	assert.Contains(t, links[0], `rel="next"`)
+	assert.Contains(t, links[1], `rel="deprecation"`)
+	assert.Empty(t, rec.Header().Get(HeaderDeprecation)) // no Since, no header
+}
@@ -0,0 +1,108 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/Jkenyut/nvx-go-helper/worker"
+)
+
+// Item is the outcome of a single operation in a 207 Multi-Status response.
+type Item struct {
+	ID         int          `json:"id"`                   // matches the job / input index
+	Success    bool         `json:"success"`              // true for 2xx
+	StatusCode int          `json:"status_code"`          // status of this item only
+	Message    string       `json:"message"`              // human-readable, lowercase
+	ErrorCode  string       `json:"error_code,omitempty"` // machine-readable code, if any
+	Errors     []FieldError `json:"errors,omitempty"`     // field errors of a failed validation
+	Data       any          `json:"data,omitempty"`       // item payload, omitted when nil
+}
+
+// MultiStatusData is the "data" of a 207 Multi-Status response.
+type MultiStatusData struct {
+	Total     int    `json:"total"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Items     []Item `json:"items"`
+}
+
+// MultiStatus builds a 207 Multi-Status response for bulk operations.
+// Every item keeps its own status code, message and data; meta.success is
+// true only when all items succeeded, and meta.message summarises the counts.
+//
+// Example JSON output:
+//
+//	{
+//	  "meta": { "success": false, "message": "1 of 2 items succeeded", "status_code": 207, ... },
+//	  "data": {
+//	    "total": 2, "succeeded": 1, "failed": 1,
+//	    "items": [
+//	      { "id": 1, "success": true, "status_code": 201, "message": "created", "data": { ... } },
+//	      { "id": 2, "success": false, "status_code": 409, "message": "email already taken" }
+//	    ]
+//	  }
+//	}
+func MultiStatus(ctx context.Context, items []Item) Response {
+	data := MultiStatusData{Total: len(items), Items: items}
+	if data.Items == nil {
+		data.Items = []Item{}
+	}
+	for _, it := range items {
+		if it.Success {
+			data.Succeeded++
+		} else {
+			data.Failed++
+		}
+	}
+
+	message := fmt.Sprintf("%d of %d items succeeded", data.Succeeded, data.Total)
+	return Response{
+		Meta: NewMeta(ctx, data.Failed == 0, message, http.StatusMultiStatus),
+		Data: data,
+	}
+}
+
+// ResultItem converts a worker result into an Item.
+// Successful results get the given status and message with the value as data;
+// failed results are mapped with FromError (keeping its field errors), and
+// worker.ErrSkipped becomes 424 Failed Dependency (the job was cancelled
+// before it ran).
+func ResultItem[R any](ctx context.Context, res worker.Result[R], status int, message string) Item {
+	if res.Err == nil {
+		return Item{ID: res.ID, Success: true, StatusCode: status, Message: message, Data: res.Value}
+	}
+
+	if errors.Is(res.Err, worker.ErrSkipped) {
+		return Item{ID: res.ID, StatusCode: http.StatusFailedDependency, Message: "not processed"}
+	}
+
+	resp := FromError(ctx, res.Err)
+	return Item{
+		ID:         res.ID,
+		Success:    resp.Meta.Success,
+		StatusCode: resp.Meta.StatusCode,
+		Message:    resp.Meta.Message,
+		ErrorCode:  resp.Meta.ErrorCode,
+		Errors:     resp.Errors,
+	}
+}
+
+// MultiStatusFromResults drains a worker result stream into a 207 Multi-Status
+// response, with items ordered by job ID. Successful items get the given
+// status and message (see ResultItem).
+//
+// Example:
+//
+//	results := worker.RunGenericWorkerPoolStream(ctx, jobs, createUser, nil, cfg)
+//	return response.MultiStatusFromResults(ctx, results, 201, "user created")
+func MultiStatusFromResults[R any](ctx context.Context, results <-chan worker.Result[R], status int, message string) Response {
+	var items []Item
+	for res := range results {
+		items = append(items, ResultItem(ctx, res, status, message))
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return MultiStatus(ctx, items)
+}
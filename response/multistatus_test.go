@@ -0,0 +1,94 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/validator"
+	"github.com/Jkenyut/nvx-go-helper/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiStatus(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-bulk-1")
+
+	resp := MultiStatus(ctx, []Item{
+		{ID: 1, Success: true, StatusCode: 201, Message: "created", Data: "a"},
+		{ID: 2, Success: false, StatusCode: 409, Message: "already exists"},
+	})
+
+	assert.Equal(t, 207, resp.Meta.StatusCode)
+	assert.False(t, resp.Meta.Success)
+	assert.Equal(t, "1 of 2 items succeeded", resp.Meta.Message)
+	assert.Equal(t, "req-bulk-1", resp.Meta.RequestID)
+
+	data := resp.Data.(MultiStatusData)
+	assert.Equal(t, 2, data.Total)
+	assert.Equal(t, 1, data.Succeeded)
+	assert.Equal(t, 1, data.Failed)
+
+	b, _ := json.Marshal(resp)
+	assert.Contains(t, string(b), `"items":[{"id":1,"success":true,"status_code":201,"message":"created","data":"a"},`+
+		`{"id":2,"success":false,"status_code":409,"message":"already exists"}]`)
+}
+
+func TestMultiStatus_AllSucceededAndEmpty(t *testing.T) {
+	resp := MultiStatus(context.Background(), []Item{{ID: 1, Success: true, StatusCode: 200, Message: "ok"}})
+	assert.True(t, resp.Meta.Success)
+
+	resp = MultiStatus(context.Background(), nil)
+	assert.True(t, resp.Meta.Success)
+	assert.Equal(t, "0 of 0 items succeeded", resp.Meta.Message)
+
+	b, _ := json.Marshal(resp)
+	assert.Contains(t, string(b), `"items":[]`)
+}
+
+func TestMultiStatusFromResults(t *testing.T) {
+	ctx := context.Background()
+	errDuplicate := errors.New("duplicate user")
+	RegisterError(errDuplicate, 409, "user already exists")
+
+	jobs := []worker.Job[int]{{ID: 3, Data: 3}, {ID: 1, Data: 1}, {ID: 2, Data: -2}, {ID: 4, Data: 0}}
+
+	results := worker.RunGenericWorkerPoolStream(ctx, jobs, func(_ context.Context, n int) (int, error) {
+		switch {
+		case n < 0:
+			return 0, errDuplicate
+		case n == 0:
+			return 0, validator.Struct(testItem{Qty: n})
+		}
+		return n * 10, nil
+	}, nil, worker.WorkerPoolConfig{NumWorkers: 2})
+
+	resp := MultiStatusFromResults(ctx, results, 201, "user created")
+
+	data := resp.Data.(MultiStatusData)
+	require.Len(t, data.Items, 4)
+	assert.Equal(t, Item{ID: 1, Success: true, StatusCode: 201, Message: "user created", Data: 10}, data.Items[0])
+	assert.Equal(t, Item{ID: 2, Success: false, StatusCode: 409, Message: "user already exists"}, data.Items[1])
+	assert.Equal(t, 3, data.Items[2].ID)
+	assert.Equal(t, 422, data.Items[3].StatusCode)
+	assert.Equal(t, []FieldError{
+		{Field: "qty", Rule: "min", Param: "1", Message: "qty must be at least 1"},
+	}, data.Items[3].Errors)
+	assert.False(t, resp.Meta.Success)
+	assert.Equal(t, "2 of 4 items succeeded", resp.Meta.Message)
+
+	b, _ := json.Marshal(data.Items[3])
+	assert.Contains(t, string(b), `"errors":[{"field":"qty"`)
+}
+
+func TestResultItem_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	skipped := ResultItem(ctx, worker.Result[int]{ID: 4, Err: worker.ErrSkipped}, 200, "ok")
+	assert.Equal(t, Item{ID: 4, StatusCode: 424, Message: "not processed"}, skipped)
+
+	failed := ResultItem(ctx, worker.Result[int]{ID: 5, Err: errors.New("db down")}, 200, "ok")
+	assert.Equal(t, Item{ID: 5, StatusCode: 500, Message: "internal server error"}, failed)
+}
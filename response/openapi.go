@@ -0,0 +1,405 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+)
+
+// Schema is an OpenAPI 3.1 (JSON Schema 2020-12) schema object.
+// Only the keywords produced by OpenAPI are modelled.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// OpenAPI collects component schemas and responses for an OpenAPI 3.1 document.
+// Struct types become named schemas referenced with $ref, package-qualified
+// (e.g. "billing.Meta") when their name is already taken; the envelope
+// schemas (Meta, FieldError, Pagination, Cursor, ErrorResponse, Problem) and the
+// standard error responses are always included.
+//
+// Example:
+//
+//	doc := response.NewOpenAPI()
+//	doc.Envelope("UserResponse", User{})
+//	doc.PaginatedEnvelope("UserListResponse", User{})
+//	out, _ := json.MarshalIndent(doc, "", "  ") // {"schemas": {...}, "responses": {...}}
+type OpenAPI struct {
+	schemas   map[string]*Schema
+	responses map[string]any
+	names     map[reflect.Type]string // struct type → component name
+	envelopes map[string]bool         // component names registered by Envelope / PaginatedEnvelope
+}
+
+// NewOpenAPI returns a generator pre-populated with the envelope components.
+func NewOpenAPI() *OpenAPI {
+	g := &OpenAPI{
+		schemas:   make(map[string]*Schema),
+		responses: make(map[string]any),
+		names:     make(map[reflect.Type]string),
+		envelopes: make(map[string]bool),
+	}
+
+	// The envelope types carry no validate tags; list their always-present members
+	g.Schema(Meta{})
+	g.schemas["Meta"].Required = []string{"success", "message", "status_code", "request_id"}
+	g.Schema(FieldError{})
+	g.schemas["FieldError"].Required = []string{"field", "rule", "message"}
+	g.Schema(pagination.Pagination{})
+	g.Schema(pagination.Cursor{})
+	g.schemas["Cursor"].Required = []string{"limit", "has_more", "has_prev"}
+	g.schemas["ErrorResponse"] = &Schema{
+		Type:     "object",
+		Required: []string{"meta"},
+		Properties: map[string]*Schema{
+			"meta":   g.Schema(Meta{}),
+			"errors": {Type: "array", Items: g.Schema(FieldError{})},
+		},
+	}
+	g.schemas["Problem"] = &Schema{
+		Type:        "object",
+		Description: "RFC 9457 problem details",
+		Required:    []string{"type", "title", "status"},
+		Properties: map[string]*Schema{
+			"type":       {Type: "string", Format: "uri-reference"},
+			"title":      {Type: "string"},
+			"status":     {Type: "integer"},
+			"detail":     {Type: "string"},
+			"instance":   {Type: "string", Format: "uri-reference"},
+			"request_id": {Type: "string"},
+			"error_code": {Type: "string"},
+			"errors":     {Type: "array", Items: g.Schema(FieldError{})},
+		},
+	}
+
+	for _, status := range []int{
+		http.StatusBadRequest,
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusNotFound,
+		http.StatusConflict,
+		http.StatusUnprocessableEntity,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+	} {
+		g.responses[componentName(http.StatusText(status))] = map[string]any{
+			"description": strings.ToLower(http.StatusText(status)),
+			"content": map[string]any{
+				"application/json":         map[string]any{"schema": &Schema{Ref: schemaRef("ErrorResponse")}},
+				"application/problem+json": map[string]any{"schema": &Schema{Ref: schemaRef("Problem")}},
+			},
+		}
+	}
+	return g
+}
+
+// Schema returns the schema of v's type, registering struct types as components.
+func (g *OpenAPI) Schema(v any) *Schema {
+	return g.schemaOf(reflect.TypeOf(v))
+}
+
+// Envelope registers a success envelope component called name whose "data" is v's type,
+// and returns a reference to it. It panics when name is already used by
+// another component (a built-in envelope schema or a struct type).
+//
+// Example:
+//
+//	doc.Envelope("UserResponse", User{}) // {"meta": Meta, "data": User}
+func (g *OpenAPI) Envelope(name string, v any) *Schema {
+	g.claimEnvelope(name)
+	g.schemas[name] = &Schema{
+		Type:     "object",
+		Required: []string{"meta"},
+		Properties: map[string]*Schema{
+			"meta": g.Schema(Meta{}),
+			"data": g.Schema(v),
+		},
+	}
+	return &Schema{Ref: schemaRef(name)}
+}
+
+// PaginatedEnvelope registers a list envelope component called name whose "data"
+// is an array of v's type and which carries "pagination" (see Paginated).
+// Like Envelope, it panics when name is already used by another component.
+func (g *OpenAPI) PaginatedEnvelope(name string, v any) *Schema {
+	g.claimEnvelope(name)
+	g.schemas[name] = &Schema{
+		Type:     "object",
+		Required: []string{"meta", "data", "pagination"},
+		Properties: map[string]*Schema{
+			"meta":       g.Schema(Meta{}),
+			"data":       {Type: "array", Items: g.Schema(v)},
+			"pagination": g.Schema(pagination.Pagination{}),
+		},
+	}
+	return &Schema{Ref: schemaRef(name)}
+}
+
+// claimEnvelope reserves name for an envelope component. Registering the same
+// envelope name again replaces it; any other existing component is kept.
+func (g *OpenAPI) claimEnvelope(name string) {
+	if _, taken := g.schemas[name]; taken && !g.envelopes[name] {
+		panic("response: openapi component " + name + " already exists")
+	}
+	g.envelopes[name] = true
+}
+
+// CursorPaginatedEnvelope registers a list envelope component called name whose
+// "data" is an array of v's type and which carries "cursor" (see CursorPaginated).
+// Like Envelope, it panics when name is already used by another component.
+func (g *OpenAPI) CursorPaginatedEnvelope(name string, v any) *Schema {
+	g.claimEnvelope(name)
+	g.schemas[name] = &Schema{
+		Type:     "object",
+		Required: []string{"meta", "data", "cursor"},
+		Properties: map[string]*Schema{
+			"meta":   g.Schema(Meta{}),
+			"data":   {Type: "array", Items: g.Schema(v)},
+			"cursor": g.Schema(pagination.Cursor{}),
+		},
+	}
+	return &Schema{Ref: schemaRef(name)}
+}
+
+// MarshalJSON encodes the generator as an OpenAPI "components" object.
+func (g *OpenAPI) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"schemas":   g.schemas,
+		"responses": g.responses,
+	})
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// schemaOf builds the schema of t. Named struct types are registered once and
+// referenced, which also terminates recursive types.
+func (g *OpenAPI) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{} // any
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes []byte as base64
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name, ok := g.names[t]
+		if !ok {
+			name = g.uniqueName(t)
+			// Reserve the name first so recursive types end in a $ref and
+			// nested types of the same name pick another one
+			g.names[t] = name
+			g.schemas[name] = nil
+			g.schemas[name] = g.structSchema(t)
+		}
+		return &Schema{Ref: schemaRef(name)}
+	default:
+		// interfaces (any) accept every value
+		return &Schema{}
+	}
+}
+
+// uniqueName returns a free component name for the struct type t: its type
+// name, package-qualified (e.g. "billing.Meta") when another component already
+// uses it, with a numeric suffix as a last resort.
+func (g *OpenAPI) uniqueName(t reflect.Type) string {
+	name := componentName(t.Name())
+	if _, taken := g.schemas[name]; !taken {
+		return name
+	}
+
+	name = componentName(path.Base(t.PkgPath()) + "." + t.Name())
+	base := name
+	for i := 2; ; i++ {
+		if _, taken := g.schemas[name]; !taken {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// structSchema builds an object schema following encoding/json field rules.
+func (g *OpenAPI) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	return s
+}
+
+// addFields adds the exported fields of t to s, flattening embedded structs.
+func (g *OpenAPI) addFields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			g.addFields(s, ft)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := g.schemaOf(f.Type)
+		if applyValidateTag(prop, f.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// applyValidateTag maps go-playground/validator rules onto schema keywords.
+// It reports whether the field is required.
+func applyValidateTag(s *Schema, tag string) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		switch key {
+		case "dive":
+			// Rules after dive apply to the elements
+			return required
+		case "required":
+			required = true
+		case "min", "gte":
+			setBound(s, param, &s.Minimum, &s.MinLength, &s.MinItems)
+		case "max", "lte":
+			setBound(s, param, &s.Maximum, &s.MaxLength, &s.MaxItems)
+		case "len":
+			setBound(s, param, &s.Minimum, &s.MinLength, &s.MinItems)
+			setBound(s, param, &s.Maximum, &s.MaxLength, &s.MaxItems)
+		case "gt":
+			if s.Type == "integer" || s.Type == "number" {
+				s.ExclusiveMinimum = parseFloat(param)
+			}
+		case "lt":
+			if s.Type == "integer" || s.Type == "number" {
+				s.ExclusiveMaximum = parseFloat(param)
+			}
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				s.Enum = append(s.Enum, v)
+			}
+		case "email":
+			s.Format = "email"
+		case "uuid", "uuid4", "uuid7":
+			s.Format = "uuid"
+		case "url", "uri":
+			s.Format = "uri"
+		case "datetime":
+			s.Format = "date-time"
+		}
+	}
+	return required
+}
+
+// setBound applies a min/max rule as a value, length or item-count bound
+// depending on the schema type; other types are left unchanged.
+func setBound(s *Schema, param string, value **float64, length, items **int) {
+	switch s.Type {
+	case "integer", "number":
+		*value = parseFloat(param)
+	case "string":
+		if n, err := strconv.Atoi(param); err == nil {
+			*length = &n
+		}
+	case "array":
+		if n, err := strconv.Atoi(param); err == nil {
+			*items = &n
+		}
+	}
+}
+
+// parseFloat returns param as a float pointer, or nil when it is not a number.
+func parseFloat(param string) *float64 {
+	f, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+// schemaRef returns the $ref of a component schema.
+func schemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// invalidComponentChars matches characters not allowed in component names.
+var invalidComponentChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// componentName turns a Go type name (including generic instantiations such as
+// "Typed[main.User]") or a status text into a valid component name.
+func componentName(name string) string {
+	name = strings.ReplaceAll(name, " ", "")
+	return strings.Trim(invalidComponentChars.ReplaceAllString(name, "_"), "_")
+}
@@ -0,0 +1,149 @@
+package response
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAuditInfo struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testAccount struct {
+	testAuditInfo
+	ID       int64             `json:"id" validate:"required"`
+	Email    string            `json:"email" validate:"required,email"`
+	Name     string            `json:"name" validate:"min=3,max=50"`
+	Age      *int              `json:"age,omitempty" validate:"omitempty,gte=17,lt=120"`
+	Status   string            `json:"status" validate:"oneof=active blocked"`
+	Tags     []string          `json:"tags" validate:"max=5,dive,min=2"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Avatar   []byte            `json:"avatar,omitempty"`
+	Parent   *testAccount      `json:"parent,omitempty"`
+	Password string            `json:"-"`
+	internal string
+}
+
+func TestOpenAPI_Schema(t *testing.T) {
+	doc := NewOpenAPI()
+	ref := doc.Schema(testAccount{})
+	assert.Equal(t, "#/components/schemas/testAccount", ref.Ref)
+
+	s := doc.schemas["testAccount"]
+	require.NotNil(t, s)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"id", "email"}, s.Required)
+
+	p := s.Properties
+	assert.NotContains(t, p, "Password")
+	assert.NotContains(t, p, "internal")
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, p["created_at"]) // embedded, flattened
+	assert.Equal(t, &Schema{Type: "integer", Format: "int64"}, p["id"])
+	assert.Equal(t, "email", p["email"].Format)
+	assert.Equal(t, 3, *p["name"].MinLength)
+	assert.Equal(t, 50, *p["name"].MaxLength)
+	assert.Equal(t, 17.0, *p["age"].Minimum)
+	assert.Equal(t, 120.0, *p["age"].ExclusiveMaximum)
+	assert.Equal(t, []any{"active", "blocked"}, p["status"].Enum)
+	assert.Equal(t, 5, *p["tags"].MaxItems)
+	assert.Nil(t, p["tags"].MinItems) // rules after dive apply to elements
+	assert.Equal(t, &Schema{Type: "string"}, p["labels"].AdditionalProperties)
+	assert.Equal(t, "byte", p["avatar"].Format)
+	assert.Equal(t, "#/components/schemas/testAccount", p["parent"].Ref) // recursion ends in $ref
+}
+
+func TestOpenAPI_Envelopes(t *testing.T) {
+	doc := NewOpenAPI()
+	assert.Equal(t, "#/components/schemas/AccountResponse", doc.Envelope("AccountResponse", testAccount{}).Ref)
+	doc.PaginatedEnvelope("AccountListResponse", testAccount{})
+	doc.CursorPaginatedEnvelope("AccountFeedResponse", testAccount{})
+
+	out, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var got struct {
+		Schemas   map[string]map[string]any `json:"schemas"`
+		Responses map[string]map[string]any `json:"responses"`
+	}
+	require.NoError(t, json.Unmarshal(out, &got))
+
+	for _, name := range []string{"Meta", "FieldError", "Pagination", "Cursor", "ErrorResponse", "Problem", "Deprecation", "testAccount"} {
+		assert.Contains(t, got.Schemas, name)
+	}
+	for _, name := range []string{"BadRequest", "NotFound", "UnprocessableEntity", "InternalServerError"} {
+		assert.Contains(t, got.Responses, name)
+	}
+
+	single := got.Schemas["AccountResponse"]["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Meta"}, single["meta"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/testAccount"}, single["data"])
+
+	list := got.Schemas["AccountListResponse"]
+	assert.Equal(t, []any{"meta", "data", "pagination"}, list["required"])
+	assert.Equal(t, "array", list["properties"].(map[string]any)["data"].(map[string]any)["type"])
+
+	feed := got.Schemas["AccountFeedResponse"]
+	assert.Equal(t, []any{"meta", "data", "cursor"}, feed["required"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Cursor"}, feed["properties"].(map[string]any)["cursor"])
+
+	meta := got.Schemas["Meta"]["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "boolean"}, meta["success"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Deprecation"}, meta["deprecation"])
+}
+
+func TestOpenAPI_NameClashes(t *testing.T) {
+	type Meta struct {
+		Author string `json:"author"`
+	}
+	type Problem struct {
+		Code int `json:"code"`
+	}
+	type Order struct {
+		Meta    Meta    `json:"meta"`
+		Problem Problem `json:"problem"`
+	}
+
+	doc := NewOpenAPI()
+	order := doc.Schema(Order{})
+	doc.Envelope("OrderResponse", Order{})
+	// A second, distinct type of the same name
+	other := func() *Schema {
+		type Meta struct {
+			Version int `json:"version"`
+		}
+		return doc.Schema(Meta{})
+	}()
+
+	assert.Equal(t, "#/components/schemas/Order", order.Ref)
+	assert.Equal(t, "#/components/schemas/response.Meta2", other.Ref)
+
+	out, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var got struct {
+		Schemas map[string]map[string]any `json:"schemas"`
+	}
+	require.NoError(t, json.Unmarshal(out, &got))
+
+	// The built-in envelope components are untouched
+	assert.Contains(t, got.Schemas["Meta"]["properties"], "request_id")
+	assert.Contains(t, got.Schemas["Problem"]["properties"], "title")
+
+	props := got.Schemas["Order"]["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/response.Meta"}, props["meta"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/response.Problem"}, props["problem"])
+	assert.Contains(t, got.Schemas["response.Meta"]["properties"], "author")
+	assert.Contains(t, got.Schemas["response.Meta2"]["properties"], "version")
+
+	assert.Panics(t, func() { doc.Envelope("Meta", Order{}) })
+	assert.Panics(t, func() { doc.PaginatedEnvelope("Order", Order{}) })
+	assert.NotPanics(t, func() { doc.Envelope("OrderResponse", Order{}) })
+}
+
+func TestComponentName(t *testing.T) {
+	assert.Equal(t, "InternalServerError", componentName("Internal Server Error"))
+	assert.Equal(t, "Typed_main.User", componentName("Typed[main.User]"))
+}
@@ -0,0 +1,162 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// ContentTypeProblem is the media type defined by RFC 9457 for problem details.
+const ContentTypeProblem = "application/problem+json"
+
+// Mode selects how error responses are rendered.
+type Mode int32
+
+const (
+	// ModeEnvelope renders every response as the standard meta/data envelope (default).
+	ModeEnvelope Mode = iota
+	// ModeProblem renders error responses (4xx/5xx) as RFC 9457 problem details.
+	// Success responses always keep the meta/data envelope.
+	ModeProblem
+)
+
+// defaultMode holds the service-wide rendering mode.
+var defaultMode atomic.Int32
+
+// SetMode sets the service-wide rendering mode for error responses.
+// Call it once at startup. Clients that send "Accept: application/problem+json"
+// always receive problem details, regardless of the mode.
+//
+// Example:
+//
+//	response.SetMode(response.ModeProblem)
+func SetMode(m Mode) {
+	defaultMode.Store(int32(m))
+}
+
+// CurrentMode returns the service-wide rendering mode.
+func CurrentMode() Mode {
+	return Mode(defaultMode.Load())
+}
+
+// Problem is an RFC 9457 problem details object.
+// Extensions are flattened into the top-level JSON object.
+//
+// Example JSON output:
+//
+//	{
+//	  "type": "about:blank",
+//	  "title": "Not Found",
+//	  "status": 404,
+//	  "detail": "user not found",
+//	  "instance": "/users/42",
+//	  "request_id": "0192c84f-..."
+//	}
+type Problem struct {
+	Type       string         // URI reference identifying the problem type
+	Title      string         // short, human-readable summary of the problem type
+	Status     int            // HTTP status code
+	Detail     string         // explanation specific to this occurrence
+	Instance   string         // URI reference identifying this occurrence
+	Extensions map[string]any // extension members (request_id, ...)
+}
+
+// Problem converts the envelope into RFC 9457 problem details.
+// The message becomes the detail; the request ID, error code, data and field errors
+// become extension members.
+func (r Response) Problem() Problem {
+	status := r.statusCode()
+
+	ext := map[string]any{"request_id": r.Meta.RequestID}
+	if r.Meta.ErrorCode != "" {
+		ext["error_code"] = r.Meta.ErrorCode
+	}
+	if r.Data != nil {
+		ext["data"] = r.Data
+	}
+	if len(r.Errors) > 0 {
+		ext["errors"] = r.Errors
+	}
+
+	return Problem{
+		Type:       "about:blank", // RFC 9457: title must then be the status phrase
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     r.Meta.Message,
+		Extensions: ext,
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+// Standard members always win over extensions with the same name.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		out[k] = v
+	}
+
+	out["type"] = p.Type
+	out["title"] = p.Title
+	out["status"] = p.Status
+	if p.Detail != "" {
+		out["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		out["instance"] = p.Instance
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Unknown members are collected into Extensions.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+	for k, v := range raw {
+		switch k {
+		case "type":
+			p.Type, _ = v.(string)
+		case "title":
+			p.Title, _ = v.(string)
+		case "status":
+			f, _ := v.(float64)
+			p.Status = int(f)
+		case "detail":
+			p.Detail, _ = v.(string)
+		case "instance":
+			p.Instance, _ = v.(string)
+		default:
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]any)
+			}
+			p.Extensions[k] = v
+		}
+	}
+	return nil
+}
+
+// wantsProblem reports whether resp should be rendered as problem details.
+func wantsProblem(req *http.Request, resp Response) bool {
+	if resp.Meta.Success {
+		return false
+	}
+	if CurrentMode() == ModeProblem {
+		return true
+	}
+	return req != nil && accepts(req.Header.Get("Accept"), ContentTypeProblem)
+}
+
+// accepts reports whether the Accept header explicitly lists mediaType with q > 0.
+func accepts(accept, mediaType string) bool {
+	for _, r := range parseAccept(accept) {
+		if r.mediaType == mediaType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponse_Problem(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-problem-1")
+
+	p := NotFound(ctx, "user not found").Problem()
+
+	assert.Equal(t, "about:blank", p.Type)
+	assert.Equal(t, "Not Found", p.Title)
+	assert.Equal(t, 404, p.Status)
+	assert.Equal(t, "user not found", p.Detail)
+	assert.Equal(t, "req-problem-1", p.Extensions["request_id"])
+}
+
+func TestProblem_JSONRoundTrip(t *testing.T) {
+	p := Problem{
+		Type:       "https://example.com/probs/out-of-credit",
+		Title:      "Forbidden",
+		Status:     403,
+		Detail:     "balance is too low",
+		Instance:   "/accounts/12345",
+		Extensions: map[string]any{"request_id": "abc", "status": "ignored"},
+	}
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"status":403`) // standard member wins
+	assert.Contains(t, string(data), `"request_id":"abc"`)
+
+	var got Problem
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, p.Type, got.Type)
+	assert.Equal(t, p.Title, got.Title)
+	assert.Equal(t, p.Status, got.Status)
+	assert.Equal(t, p.Detail, got.Detail)
+	assert.Equal(t, p.Instance, got.Instance)
+	assert.Equal(t, map[string]any{"request_id": "abc"}, got.Extensions)
+}
+
+func TestRender_ProblemViaAcceptHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	req.Header.Set("Accept", "application/problem+json, application/json;q=0.5")
+	ctx := activity.WithRequestID(req.Context(), "req-problem-2")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, NotFound(ctx, "user not found")))
+
+	assert.Equal(t, 404, rec.Code)
+	assert.Equal(t, ContentTypeProblem, rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "user not found", body["detail"])
+	assert.Equal(t, "/users/42", body["instance"])
+	assert.Equal(t, "req-problem-2", body["request_id"])
+	assert.NotContains(t, body, "meta")
+}
+
+func TestRender_ProblemKeepsEnvelopeForSuccess(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(req.Context(), "user found", "data")))
+
+	assert.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"meta"`)
+}
+
+func TestRender_ProblemIgnoresZeroQuality(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	req.Header.Set("Accept", "application/problem+json;q=0")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, NotFound(req.Context(), "user not found")))
+
+	assert.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+}
+
+func TestSetMode_Problem(t *testing.T) {
+	SetMode(ModeProblem)
+	defer SetMode(ModeEnvelope)
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, BadRequest(context.Background(), "invalid input").Write(rec))
+
+	assert.Equal(t, 400, rec.Code)
+	assert.Equal(t, ContentTypeProblem, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"title":"Bad Request"`)
+}
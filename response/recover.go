@@ -0,0 +1,166 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+)
+
+// RecoverMiddleware recovers panics in next, logs them with their stack trace
+// and the activity fields of the request, and answers with an InternalError
+// envelope that carries the request ID (from the context, or the X-Request-ID
+// header). A nil logger uses slog.Default().
+//
+// When the handler had already started the response, the envelope can no
+// longer be sent; the panic is logged and the connection is aborted so the
+// client does not mistake the truncated body for a complete one.
+//
+// Example:
+//
+//	handler = response.RecoverMiddleware(logger)(mux)
+func RecoverMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if _, ok := activity.GetRequestID(ctx); !ok {
+				if id := r.Header.Get(HeaderRequestID); id != "" {
+					ctx = activity.WithRequestID(ctx, id)
+					r = r.WithContext(ctx)
+				}
+			}
+
+			rw := &recoverWriter{ResponseWriter: w}
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					// Deliberate abort, net/http handles it silently
+					panic(rec)
+				}
+
+				resp := InternalError(ctx)
+				logPanic(ctx, logger, r, rec, resp.Meta.RequestID)
+
+				if rw.wroteHeader {
+					panic(http.ErrAbortHandler)
+				}
+				// Headers describing the aborted representation must not leak
+				// into the 500, e.g. a Cache-Control making it cacheable
+				h := w.Header()
+				for _, k := range representationHeaders {
+					h.Del(k)
+				}
+				_ = Render(w, r, resp)
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// representationHeaders are removed before RecoverMiddleware renders the
+// 500, as the panicking handler may have set them for its own response.
+var representationHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Length",
+	"Content-Location",
+	"Content-Range",
+	"Content-Type",
+	"ETag",
+	"Expires",
+	"Last-Modified",
+	"Link",
+	"Location",
+}
+
+// logPanic writes a recovered panic to logger.
+func logPanic(ctx context.Context, logger *slog.Logger, r *http.Request, rec any, requestID string) {
+	attrs := []any{
+		slog.String("panic", fmt.Sprint(rec)),
+		slog.String("stack", string(debug.Stack())),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	}
+	fields := activity.GetAllFieldsFromContext(ctx)
+	// The request ID of the envelope, even when InternalError generated it
+	fields["nvx_request_id"] = requestID
+	for k, v := range fields {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	logger.ErrorContext(ctx, "panic recovered", attrs...)
+}
+
+// recoverWriter records whether the response was started.
+type recoverWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader marks the response as started.
+func (rw *recoverWriter) WriteHeader(status int) {
+	if status >= http.StatusOK {
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+// Write marks the response as started.
+func (rw *recoverWriter) Write(p []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.ResponseWriter.Write(p)
+}
+
+// Flush marks the response as started and flushes the underlying writer.
+func (rw *recoverWriter) Flush() {
+	rw.wroteHeader = true
+	_ = http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (rw *recoverWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// NotFoundHandler answers unmatched routes with a 404 envelope.
+// Use it as the router's not-found handler, or as the catch-all "/"
+// pattern of an http.ServeMux.
+//
+// Example:
+//
+//	mux.Handle("/", response.NotFoundHandler())
+//	router.NotFound(response.NotFoundHandler().ServeHTTP) // chi
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = Render(w, r, NotFound(r.Context(), "route not found"))
+	})
+}
+
+// MethodNotAllowedHandler answers requests whose route exists but not for
+// the method with a 405 envelope and an Allow header listing allowed.
+//
+// Example:
+//
+//	router.MethodNotAllowed(response.MethodNotAllowedHandler("GET", "POST").ServeHTTP)
+func MethodNotAllowedHandler(allowed ...string) http.Handler {
+	allow := strings.Join(allowed, ", ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if allow != "" {
+			w.Header().Set("Allow", allow)
+		}
+		_ = Render(w, r, MethodNotAllowed(r.Context(), fmt.Sprintf("method %s not allowed", r.Method)))
+	})
+}
@@ -0,0 +1,130 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	handler := RecoverMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest("POST", "/orders", nil)
+	req.Header.Set(HeaderRequestID, "req-panic-1")
+	req = req.WithContext(activity.WithUserID(req.Context(), "user-7"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, 500, rec.Code)
+	assert.Equal(t, "req-panic-1", rec.Header().Get(HeaderRequestID))
+
+	var got Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.False(t, got.Meta.Success)
+	assert.Equal(t, "internal server error", got.Meta.Message)
+	assert.Equal(t, "req-panic-1", got.Meta.RequestID)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "panic recovered", entry["msg"])
+	assert.Equal(t, "boom", entry["panic"])
+	assert.Equal(t, "/orders", entry["path"])
+	assert.Equal(t, "req-panic-1", entry["nvx_request_id"])
+	assert.Equal(t, "user-7", entry["nvx_user_id"])
+	assert.Contains(t, entry["stack"], "TestRecoverMiddleware")
+}
+
+func TestRecoverMiddleware_GeneratedRequestIDIsLogged(t *testing.T) {
+	var logs bytes.Buffer
+	handler := RecoverMiddleware(slog.New(slog.NewJSONHandler(&logs, nil)))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(io.ErrUnexpectedEOF)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.NotEmpty(t, rec.Header().Get(HeaderRequestID))
+	assert.Equal(t, rec.Header().Get(HeaderRequestID), entry["nvx_request_id"])
+}
+
+func TestRecoverMiddleware_ResponseStarted(t *testing.T) {
+	handler := RecoverMiddleware(slog.New(slog.NewTextHandler(io.Discard, nil)))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`{"meta":`))
+		panic("boom")
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	})
+}
+
+func TestRecoverMiddleware_DropsHandlerHeaders(t *testing.T) {
+	handler := RecoverMiddleware(slog.New(slog.NewTextHandler(io.Discard, nil)))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2026 15:04:05 GMT")
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Link", `</orders?page=2>; rel="next"`)
+		w.Header().Set("X-Trace", "kept")
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/orders", nil))
+
+	assert.Equal(t, 500, rec.Code)
+	assert.Empty(t, rec.Header().Get("Cache-Control"))
+	assert.Empty(t, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Header().Get("Last-Modified"))
+	assert.Empty(t, rec.Header().Get("Link"))
+	assert.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "kept", rec.Header().Get("X-Trace"))
+}
+
+func TestRecoverMiddleware_NoPanic(t *testing.T) {
+	handler := RecoverMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = Render(w, r, OK(r.Context(), "ok", nil))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, 200, rec.Code)
+}
+
+func TestNotFoundHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {})
+	mux.Handle("/", NotFoundHandler())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/missing", nil))
+
+	assert.Equal(t, 404, rec.Code)
+	assert.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"message":"route not found"`)
+}
+
+func TestMethodNotAllowedHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	MethodNotAllowedHandler("GET", "POST").ServeHTTP(rec, httptest.NewRequest("DELETE", "/users", nil))
+
+	assert.Equal(t, 405, rec.Code)
+	assert.Equal(t, "GET, POST", rec.Header().Get("Allow"))
+	assert.Contains(t, rec.Body.String(), `"message":"method DELETE not allowed"`)
+}
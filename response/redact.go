@@ -0,0 +1,277 @@
+package response
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/format"
+)
+
+// RedactMode selects how a sensitive value is rendered in logs.
+// It is also the value of the `redact` struct tag.
+type RedactMode string
+
+const (
+	RedactHide    RedactMode = "hide"    // replaced by "[REDACTED]"
+	RedactMask    RedactMode = "mask"    // first and last 2 characters kept
+	RedactAccount RedactMode = "account" // format.MaskAccount
+	RedactPhone   RedactMode = "phone"   // format.MaskPhone
+)
+
+// redactedPlaceholder replaces values redacted with RedactHide.
+const redactedPlaceholder = "[REDACTED]"
+
+// redactKey maps a key-name pattern to a redaction mode.
+type redactKey struct {
+	pattern string
+	mode    RedactMode
+}
+
+// redactKeys are matched against JSON field names and map keys, in order.
+var (
+	redactKeysMu sync.RWMutex
+	redactKeys   = []redactKey{
+		{"password", RedactHide},
+		{"*secret*", RedactHide},
+		{"*token*", RedactHide},
+		{"authorization", RedactHide},
+		{"pin", RedactHide},
+		{"otp", RedactHide},
+		{"cvv", RedactHide},
+		{"*account_number*", RedactAccount},
+		{"norek", RedactAccount},
+		{"*phone*", RedactPhone},
+		{"msisdn", RedactPhone},
+	}
+)
+
+// RedactKey registers a key-name pattern (path.Match syntax, case-insensitive)
+// whose values are redacted in Redacted copies, e.g. "*_nik" or "card_*".
+// Patterns registered later are checked after the built-in ones
+// (password, *secret*, *token*, *account_number*, *phone*, ...).
+// It panics on a malformed pattern.
+//
+// Example:
+//
+//	response.RedactKey("nik", response.RedactMask)
+func RedactKey(pattern string, mode RedactMode) {
+	pattern = strings.ToLower(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		panic("response: invalid redact pattern " + pattern)
+	}
+
+	redactKeysMu.Lock()
+	defer redactKeysMu.Unlock()
+	redactKeys = append(redactKeys, redactKey{pattern: pattern, mode: mode})
+}
+
+// Redacted returns a sanitized copy of the envelope for audit logs.
+// Sensitive values in Data are masked or hidden based on `redact` struct tags
+// (`redact:"mask"`, `redact:"hide"`, `redact:"account"`, `redact:"phone"`)
+// and on the key-name patterns registered with RedactKey.
+// The original response is left untouched.
+//
+// Example:
+//
+//	type Account struct {
+//	    Number string `json:"number" redact:"account"`
+//	    Token  string `json:"token"` // matches "*token*"
+//	}
+//
+//	logger.Info("response", "body", resp.Redacted())
+func (r Response) Redacted() Response {
+	r.Data = redactValue(reflect.ValueOf(r.Data))
+	return r
+}
+
+// redactValue walks v and returns a JSON-shaped copy with sensitive values redacted.
+func redactValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	}
+
+	if v.CanInterface() {
+		iface := v.Interface()
+		// Pointer-receiver marshalers are used by encoding/json too
+		if v.CanAddr() {
+			if m, ok := v.Addr().Interface().(json.Marshaler); ok {
+				iface = m
+			}
+		}
+
+		switch m := iface.(type) {
+		case json.RawMessage:
+			return redactJSON(m)
+		case time.Time:
+			return m
+		case json.Marshaler:
+			// Custom JSON may carry sensitive keys: redact its generic form
+			b, err := m.MarshalJSON()
+			if err != nil {
+				return redactedPlaceholder
+			}
+			return redactJSON(b)
+		case encoding.TextMarshaler:
+			// Encoded as a string (uuid.UUID, netip.Addr, ...): a leaf
+			return m
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		out := make(map[string]any)
+		redactStruct(v, out)
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if mode, ok := redactModeForKey(key); ok {
+				out[key] = redactWith(iter.Value(), mode)
+				continue
+			}
+			out[key] = redactValue(iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		// []byte is encoded as base64 by encoding/json; keep it opaque
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redactValue(v.Index(i))
+		}
+		return out
+	default:
+		if v.CanInterface() {
+			return v.Interface()
+		}
+		return nil
+	}
+}
+
+// redactJSON redacts the generic form of the JSON document b.
+// Numbers are kept as json.Number so large IDs stay exact.
+func redactJSON(b []byte) any {
+	var generic any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return redactedPlaceholder
+	}
+	return redactValue(reflect.ValueOf(generic))
+}
+
+// redactStruct copies the exported fields of v into out using JSON names,
+// flattening embedded structs like encoding/json does.
+func redactStruct(v reflect.Value, out map[string]any) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// Embedded struct without a JSON name: promote its fields
+		if sf.Anonymous && name == "" {
+			ev := fv
+			if ev.Kind() == reflect.Pointer {
+				if ev.IsNil() {
+					continue
+				}
+				ev = ev.Elem()
+			}
+			if ev.Kind() == reflect.Struct {
+				redactStruct(ev, out)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if strings.Contains(opts, "omitempty") && fv.IsZero() {
+			continue
+		}
+
+		if mode := sf.Tag.Get("redact"); mode != "" {
+			out[name] = redactWith(fv, RedactMode(mode))
+			continue
+		}
+		if mode, ok := redactModeForKey(name); ok {
+			out[name] = redactWith(fv, mode)
+			continue
+		}
+		out[name] = redactValue(fv)
+	}
+}
+
+// redactModeForKey returns the mode of the first pattern matching key.
+func redactModeForKey(key string) (RedactMode, bool) {
+	key = strings.ToLower(key)
+
+	redactKeysMu.RLock()
+	defer redactKeysMu.RUnlock()
+
+	for _, rk := range redactKeys {
+		if ok, _ := path.Match(rk.pattern, key); ok {
+			return rk.mode, true
+		}
+	}
+	return "", false
+}
+
+// redactWith renders v according to mode. Nil values stay nil.
+func redactWith(v reflect.Value, mode RedactMode) any {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+
+	s := format.ToString(v.Interface())
+	switch mode {
+	case RedactMask:
+		return format.Mask(s, 2, 2)
+	case RedactAccount:
+		return format.MaskAccount(s)
+	case RedactPhone:
+		return format.MaskPhone(s)
+	default:
+		// Unknown modes are treated as hide: never leak by accident
+		return redactedPlaceholder
+	}
+}
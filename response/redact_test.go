@@ -0,0 +1,148 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type testTransfer struct {
+	testAudit
+	ID            int               `json:"id"`
+	FromAccount   string            `json:"from_account" redact:"account"`
+	Beneficiary   string            `json:"beneficiary" redact:"mask"`
+	CardNumber    string            `json:"card_number" redact:"hide"`
+	Phone         string            `json:"phone"`
+	AccessToken   string            `json:"access_token"`
+	Note          string            `json:"note,omitempty"`
+	Internal      string            `json:"-"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Headers       map[string]string `json:"headers"`
+	PIN           *string           `json:"pin"`
+	Weird         string            `json:"weird" redact:"unknown-mode"`
+	AccountNumber string            `json:"account_number"`
+}
+
+func TestResponse_Redacted(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-redact-1")
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	transfer := testTransfer{
+		testAudit:     testAudit{CreatedBy: "budi"},
+		ID:            7,
+		FromAccount:   "1234-56-789012-34-5",
+		Beneficiary:   "Siti Aminah",
+		CardNumber:    "4111111111111111",
+		Phone:         "081234567890",
+		AccessToken:   "eyJhbGciOi",
+		Internal:      "never logged",
+		CreatedAt:     createdAt,
+		Headers:       map[string]string{"Authorization": "Bearer abc", "X-Trace": "t-1"},
+		Weird:         "value",
+		AccountNumber: "987654321",
+	}
+	resp := OK(ctx, "transfer created", []testTransfer{transfer})
+
+	redacted := resp.Redacted()
+
+	b, err := json.Marshal(redacted)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"meta": {"success":true,"message":"transfer created","status_code":200,"request_id":"req-redact-1"},
+		"data": [{
+			"created_by": "budi",
+			"id": 7,
+			"from_account": "***********2345",
+			"beneficiary": "Si*******ah",
+			"card_number": "[REDACTED]",
+			"phone": "0812*****890",
+			"access_token": "[REDACTED]",
+			"created_at": "2025-01-02T03:04:05Z",
+			"headers": {"Authorization": "[REDACTED]", "X-Trace": "t-1"},
+			"pin": null,
+			"weird": "[REDACTED]",
+			"account_number": "*****4321"
+		}]
+	}`, string(b))
+
+	// The original is untouched
+	assert.Equal(t, "4111111111111111", resp.Data.([]testTransfer)[0].CardNumber)
+}
+
+func TestResponse_RedactedMapsAndRawJSON(t *testing.T) {
+	resp := OK(context.Background(), "ok", map[string]any{
+		"Password": "hunter2",
+		"profile":  json.RawMessage(`{"msisdn":"6281234567890","name":"Budi"}`),
+		"ids":      []int{1, 2},
+	})
+
+	b, _ := json.Marshal(resp.Redacted().Data)
+
+	assert.JSONEq(t, `{
+		"Password": "[REDACTED]",
+		"profile": {"msisdn":"6281******890","name":"Budi"},
+		"ids": [1,2]
+	}`, string(b))
+}
+
+type testCard struct{ number, cvv string }
+
+func (c testCard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{"number": c.number, "cvv": c.cvv, "id": 9007199254740993})
+}
+
+type testPtrCard struct{ cvv string }
+
+func (c *testPtrCard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"cvv": c.cvv})
+}
+
+func TestResponse_RedactedCustomMarshaler(t *testing.T) {
+	resp := OK(context.Background(), "ok", struct {
+		Card    testCard     `json:"card"`
+		PtrCard *testPtrCard `json:"ptr_card"`
+		Cards   []*testCard  `json:"cards"`
+		Stamp   time.Time    `json:"stamp"`
+		Raw     *testPtrCard `json:"raw"`
+	}{
+		Card:    testCard{number: "4111111111111111", cvv: "123"},
+		PtrCard: &testPtrCard{cvv: "456"},
+		Cards:   []*testCard{{number: "5500000000000004", cvv: "789"}},
+		Stamp:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+
+	b, err := json.Marshal(resp.Redacted().Data)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"card": {"number":"4111111111111111","cvv":"[REDACTED]","id":9007199254740993},
+		"ptr_card": {"cvv":"[REDACTED]"},
+		"cards": [{"number":"5500000000000004","cvv":"[REDACTED]","id":9007199254740993}],
+		"stamp": "2025-01-02T03:04:05Z",
+		"raw": null
+	}`, string(b))
+	assert.Contains(t, string(b), "9007199254740993")
+}
+
+func TestRedactKey(t *testing.T) {
+	RedactKey("NIK", RedactMask)
+
+	resp := OK(context.Background(), "ok", map[string]string{"nik": "3171234567890001"})
+
+	assert.Equal(t, map[string]any{"nik": "31************01"}, resp.Redacted().Data)
+	assert.Panics(t, func() { RedactKey("[", RedactHide) })
+}
+
+func TestResponse_RedactedNilData(t *testing.T) {
+	resp := NotFound(context.Background(), "not found").Redacted()
+
+	assert.Nil(t, resp.Data)
+}
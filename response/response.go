@@ -26,25 +26,38 @@ package response
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/Jkenyut/nvx-go-helper/activity"
 	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
+	"github.com/Jkenyut/nvx-go-helper/pagination"
 )
 
 // Meta holds the metadata for the API response.
 // It contains status information, messages, and tracing IDs.
 type Meta struct {
-	Success    bool   `json:"success"`     // true for 2xx, false for 4xx/5xx
-	Message    string `json:"message"`     // human-readable, lowercase
-	StatusCode int    `json:"status_code"` // HTTP status code as int
-	RequestID  string `json:"request_id"`  // correlation ID for tracing
+	Success    bool   `json:"success"`              // true for 2xx, false for 4xx/5xx
+	Message    string `json:"message"`              // human-readable, lowercase
+	StatusCode int    `json:"status_code"`          // HTTP status code as int
+	RequestID  string `json:"request_id"`           // correlation ID for tracing
+	ErrorCode  string `json:"error_code,omitempty"` // machine-readable code, see RegisterCode
+
+	// Optional extensions, filled from the context (see TimingMiddleware,
+	// VersionMiddleware and DeprecationMiddleware) and omitted when unset.
+	ServerTime  time.Time    `json:"server_time,omitzero"`  // when the response was built (UTC)
+	LatencyMS   float64      `json:"latency_ms,omitempty"`  // processing time since the request started
+	APIVersion  string       `json:"api_version,omitempty"` // version serving the request
+	Deprecation *Deprecation `json:"deprecation,omitempty"` // set on deprecated endpoints
 }
 
 // Response is the standard top-level JSON structure.
 // All API endpoints must return this structure.
 type Response struct {
-	Meta Meta `json:"meta"`           // always present
-	Data any  `json:"data,omitempty"` // omitted when nil
+	Meta       Meta                   `json:"meta"`                 // always present
+	Data       any                    `json:"data,omitempty"`       // omitted when nil
+	Errors     []FieldError           `json:"errors,omitempty"`     // field-level validation errors (422)
+	Pagination *pagination.Pagination `json:"pagination,omitempty"` // set by Paginated
+	Cursor     *pagination.Cursor     `json:"cursor,omitempty"`     // set by CursorPaginated
 }
 
 // NewMeta builds metadata with correct request_id precedence:
@@ -58,13 +71,16 @@ func NewMeta(ctx context.Context, success bool, message string, status int) Meta
 		reqID = cryptoutil.V7()
 	}
 
-	// Return the constructed Meta struct
-	return Meta{
+	// Construct the Meta struct
+	meta := Meta{
 		Success:    success, // Success status
 		Message:    message, // Message string
 		StatusCode: status,  // HTTP status code
 		RequestID:  reqID,   // Tracing ID
 	}
+	// Add server time, latency, API version and deprecation when present
+	extendMeta(ctx, &meta)
+	return meta
 }
 
 // === SUCCESS RESPONSES (2xx) ===
@@ -186,6 +202,33 @@ func GatewayTimeout(ctx context.Context, message string) Response {
 	return Response{Meta: NewMeta(ctx, false, message, 504)}
 }
 
+// Paginated sends a 200 OK response with a page of items.
+// Pagination metadata is placed in the top-level "pagination" object,
+// next to "data", so every list endpoint has the same shape.
+//
+// Example:
+//
+//	p := pagination.New(q.Get("page"), q.Get("limit"), total)
+//	return response.Paginated(ctx, "users retrieved", users, p)
+func Paginated(ctx context.Context, message string, items any, p pagination.Pagination) Response {
+	return Response{Meta: NewMeta(ctx, true, message, 200), Data: items, Pagination: &p}
+}
+
+// CursorPaginated sends a 200 OK response with a cursor-paginated page of items.
+// Cursor metadata is placed in the top-level "cursor" object, next to "data",
+// and Render emits Link headers for its next and previous cursors.
+//
+// Example:
+//
+//	users, c, err := pagination.CursorPage(q, rows, userKey)
+//	if err != nil {
+//	    return response.FromError(ctx, err)
+//	}
+//	return response.CursorPaginated(ctx, "users retrieved", users, c)
+func CursorPaginated(ctx context.Context, message string, items any, c pagination.Cursor) Response {
+	return Response{Meta: NewMeta(ctx, true, message, 200), Data: items, Cursor: &c}
+}
+
 // === HELPERS ===
 
 // Success is a shortcut for OK(ctx, "success", data).
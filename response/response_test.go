@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewMeta_UsesRequestIDFromContext(t *testing.T) {
@@ -129,3 +131,42 @@ func TestResponse_WithMessage(t *testing.T) {
 	assert.Contains(t, jsonStrErr, `"success":false`)
 	assert.Contains(t, jsonStrErr, `"status_code":400`)
 }
+
+func TestPaginated(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-page-1")
+	p := pagination.New("2", "10", 35)
+
+	resp := Paginated(ctx, "users retrieved", []string{"a", "b"}, p)
+
+	assert.True(t, resp.Meta.Success)
+	assert.Equal(t, 200, resp.Meta.StatusCode)
+	require.NotNil(t, resp.Pagination)
+	assert.Equal(t, 4, resp.Pagination.TotalPages)
+
+	data, _ := json.Marshal(resp)
+	assert.Contains(t, string(data), `"data":["a","b"]`)
+	assert.Contains(t, string(data), `"pagination":{"page":2,"limit":10,"total":35,"total_pages":4`)
+}
+
+func TestResponse_PaginationOmittedWhenNil(t *testing.T) {
+	data, _ := json.Marshal(OK(context.Background(), "ok", "data"))
+
+	assert.NotContains(t, string(data), "pagination")
+	assert.NotContains(t, string(data), "cursor")
+}
+
+func TestCursorPaginated(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-cursor-1")
+	c := pagination.Cursor{Limit: 2, HasMore: true, NextCursor: "abc"}
+
+	resp := CursorPaginated(ctx, "users retrieved", []string{"a", "b"}, c)
+
+	assert.True(t, resp.Meta.Success)
+	assert.Equal(t, 200, resp.Meta.StatusCode)
+	assert.Nil(t, resp.Pagination)
+	require.NotNil(t, resp.Cursor)
+
+	data, _ := json.Marshal(resp)
+	assert.Contains(t, string(data), `"data":["a","b"]`)
+	assert.Contains(t, string(data), `"cursor":{"limit":2,"has_more":true,"has_prev":false,"next_cursor":"abc"}`)
+}
@@ -0,0 +1,218 @@
+// Package responsetest provides fluent assertions for handlers that write
+// response envelopes, so handler tests stop unmarshalling meta/data by hand.
+//
+// Example:
+//
+//	rec := httptest.NewRecorder()
+//	handler.ServeHTTP(rec, req)
+//
+//	responsetest.New(t, rec).
+//	    Status(201).
+//	    Success().
+//	    Message("user created").
+//	    Data("name", "Budi").
+//	    Header("X-Request-ID")
+package responsetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// Assertion wraps a recorded envelope response.
+// Every method reports failures through t (with testify's diffs) and returns
+// the Assertion so checks can be chained.
+type Assertion struct {
+	t    testing.TB
+	rec  *httptest.ResponseRecorder
+	env  response.Typed[json.RawMessage]
+	data any // generic form of env.Data, numbers kept as json.Number
+}
+
+// New decodes rec as a response envelope.
+// The test stops immediately when the body is not an envelope.
+func New(t testing.TB, rec *httptest.ResponseRecorder) *Assertion {
+	t.Helper()
+
+	a := &Assertion{t: t, rec: rec}
+	var raw struct {
+		Meta *response.Meta `json:"meta"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil || raw.Meta == nil {
+		t.Fatalf("responsetest: body is not a response envelope: %s", rec.Body.String())
+		return a
+	}
+	// Meta was valid, so the envelope decodes as well
+	_ = json.Unmarshal(rec.Body.Bytes(), &a.env)
+
+	if len(a.env.Data) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(a.env.Data))
+		dec.UseNumber()
+		_ = dec.Decode(&a.data)
+	}
+	return a
+}
+
+// Meta returns the decoded meta.
+func (a *Assertion) Meta() response.Meta {
+	return a.env.Meta
+}
+
+// Envelope returns the decoded envelope with the raw data.
+func (a *Assertion) Envelope() response.Typed[json.RawMessage] {
+	return a.env
+}
+
+// Status asserts the HTTP status code and meta.status_code.
+func (a *Assertion) Status(code int) *Assertion {
+	a.t.Helper()
+	assert.Equal(a.t, code, a.rec.Code, "http status")
+	assert.Equal(a.t, code, a.env.Meta.StatusCode, "meta.status_code")
+	return a
+}
+
+// Success asserts meta.success is true.
+func (a *Assertion) Success() *Assertion {
+	a.t.Helper()
+	assert.True(a.t, a.env.Meta.Success, "meta.success: %s", a.env.Meta.Message)
+	return a
+}
+
+// Failure asserts meta.success is false.
+func (a *Assertion) Failure() *Assertion {
+	a.t.Helper()
+	assert.False(a.t, a.env.Meta.Success, "meta.success: %s", a.env.Meta.Message)
+	return a
+}
+
+// Message asserts meta.message.
+func (a *Assertion) Message(message string) *Assertion {
+	a.t.Helper()
+	assert.Equal(a.t, message, a.env.Meta.Message, "meta.message")
+	return a
+}
+
+// RequestID asserts meta.request_id and the X-Request-ID header.
+func (a *Assertion) RequestID(id string) *Assertion {
+	a.t.Helper()
+	assert.Equal(a.t, id, a.env.Meta.RequestID, "meta.request_id")
+	assert.Equal(a.t, id, a.rec.Header().Get(response.HeaderRequestID), "header %s", response.HeaderRequestID)
+	return a
+}
+
+// ErrorCode asserts meta.error_code.
+func (a *Assertion) ErrorCode(code string) *Assertion {
+	a.t.Helper()
+	assert.Equal(a.t, code, a.env.Meta.ErrorCode, "meta.error_code")
+	return a
+}
+
+// Header asserts that the header is present.
+func (a *Assertion) Header(name string) *Assertion {
+	a.t.Helper()
+	assert.NotEmpty(a.t, a.rec.Header().Values(name), "header %s is missing", name)
+	return a
+}
+
+// HeaderValue asserts the value of a header.
+func (a *Assertion) HeaderValue(name, value string) *Assertion {
+	a.t.Helper()
+	assert.Equal(a.t, value, a.rec.Header().Get(name), "header %s", name)
+	return a
+}
+
+// Data asserts the value at a dot-separated path inside "data"
+// (numeric segments index arrays, "" is the whole data).
+// Values are compared by their JSON form, so 1, int64(1) and 1.0 are equal.
+//
+// Example:
+//
+//	a.Data("items.0.name", "Budi")
+//	a.Data("", map[string]any{"id": 1})
+func (a *Assertion) Data(path string, expected any) *Assertion {
+	a.t.Helper()
+
+	actual, err := lookup(a.data, path)
+	if err != nil {
+		a.t.Errorf("responsetest: data %s\ndata: %s", err, a.env.Data)
+		return a
+	}
+
+	want, err := json.Marshal(expected)
+	if err != nil {
+		a.t.Errorf("responsetest: marshal expected value: %v", err)
+		return a
+	}
+	got, _ := json.Marshal(actual)
+	assert.JSONEq(a.t, string(want), string(got), "data path %q", path)
+	return a
+}
+
+// NoData asserts that "data" is absent or null.
+func (a *Assertion) NoData() *Assertion {
+	a.t.Helper()
+	assert.Nil(a.t, a.data, "data")
+	return a
+}
+
+// FieldError asserts that "errors" contains an entry for field with rule.
+func (a *Assertion) FieldError(field, rule string) *Assertion {
+	a.t.Helper()
+	for _, fe := range a.env.Errors {
+		if fe.Field == field && fe.Rule == rule {
+			return a
+		}
+	}
+	a.t.Errorf("responsetest: no field error %s/%s in %+v", field, rule, a.env.Errors)
+	return a
+}
+
+// DataAs decodes "data" into T for assertions that need the typed value.
+//
+// Example:
+//
+//	user := responsetest.DataAs[User](t, rec)
+func DataAs[T any](t testing.TB, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	env, err := response.DecodeTyped[T](bytes.NewReader(rec.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("responsetest: decode data: %v", err)
+	}
+	return env.Data
+}
+
+// lookup walks a dot-separated path through decoded JSON.
+func lookup(v any, path string) (any, error) {
+	if path == "" {
+		return v, nil
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			child, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("path %q: key %q not found", path, key)
+			}
+			v = child
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("path %q: index %q out of range (len %d)", path, key, len(node))
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("path %q: cannot descend into %T at %q", path, v, key)
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,107 @@
+package responsetest
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT records failures instead of failing the real test.
+type fakeT struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Name() string { return "fake" }
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	f.fatal = true
+}
+
+type user struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+func record(t *testing.T, resp response.Response) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	require.NoError(t, resp.Write(rec))
+	return rec
+}
+
+func TestAssertion_Pass(t *testing.T) {
+	ctx := activity.WithRequestID(t.Context(), "req-rt-1")
+	rec := record(t, response.Created(ctx, "user created", user{ID: 7, Name: "Budi", Roles: []string{"admin"}}))
+
+	New(t, rec).
+		Status(201).
+		Success().
+		Message("user created").
+		RequestID("req-rt-1").
+		Header("Content-Type").
+		HeaderValue(response.HeaderRequestID, "req-rt-1").
+		Data("id", 7).
+		Data("roles.0", "admin").
+		Data("", map[string]any{"id": 7.0, "name": "Budi", "roles": []string{"admin"}})
+
+	assert.Equal(t, user{ID: 7, Name: "Budi", Roles: []string{"admin"}}, DataAs[user](t, rec))
+}
+
+func TestAssertion_Failures(t *testing.T) {
+	ctx := activity.WithRequestID(t.Context(), "req-rt-2")
+	rec := record(t, response.OK(ctx, "ok", user{ID: 1, Name: "Budi"}))
+
+	ft := &fakeT{}
+	New(ft, rec).
+		Status(404).
+		Failure().
+		Message("nope").
+		Header("X-Missing").
+		Data("name", "Siti").
+		Data("roles.3", "admin").
+		Data("age", 1).
+		NoData()
+
+	require.Len(t, ft.errors, 9) // Status reports both http and meta status
+	assert.Contains(t, ft.errors[5], `"Siti"`)
+	assert.Contains(t, ft.errors[6], `cannot descend into <nil>`)
+	assert.Contains(t, ft.errors[7], `key "age" not found`)
+}
+
+func TestAssertion_ErrorEnvelope(t *testing.T) {
+	rec := record(t, response.NotFound(t.Context(), "user not found"))
+	New(t, rec).Status(404).Failure().Message("user not found").NoData()
+
+	rec = record(t, response.Response{
+		Meta:   response.Meta{StatusCode: 422, Message: "validation failed", ErrorCode: "USER_INVALID"},
+		Errors: []response.FieldError{{Field: "email", Rule: "required"}},
+	})
+	New(t, rec).Status(422).ErrorCode("USER_INVALID").FieldError("email", "required")
+
+	ft := &fakeT{}
+	New(ft, rec).FieldError("email", "min")
+	assert.Len(t, ft.errors, 1)
+}
+
+func TestNew_NotEnvelope(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.WriteString("plain text")
+
+	ft := &fakeT{}
+	New(ft, rec)
+	assert.True(t, ft.fatal)
+}
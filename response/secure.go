@@ -0,0 +1,135 @@
+package response
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
+)
+
+// Header names used for signed responses.
+const (
+	HeaderSignature          = "X-Signature"           // hex HMAC-SHA256 of "<timestamp>.<body>"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp" // unix seconds used in the signature
+)
+
+// Errors returned by VerifySignature.
+var (
+	ErrMissingSignature = errors.New("response: missing signature")
+	ErrInvalidSignature = errors.New("response: invalid signature")
+	ErrExpiredSignature = errors.New("response: signature timestamp outside tolerance")
+)
+
+// EncryptedData replaces "data" when WithEncryption is used.
+//
+// Example JSON output:
+//
+//	"data": { "alg": "A256GCM", "ciphertext": "q83vEjRWeJ..." }
+type EncryptedData struct {
+	Alg        string `json:"alg"`        // always "A256GCM"
+	Ciphertext string `json:"ciphertext"` // cryptoutil.AESGCM output (URL-safe base64)
+}
+
+// WithEncryption encrypts "data" with enc, placing the ciphertext in the envelope.
+// Meta stays readable so clients can still branch on status and request ID.
+//
+// Example:
+//
+//	response.Render(w, r, resp, response.WithEncryption(partnerAES))
+func WithEncryption(enc *cryptoutil.AESGCM) Option {
+	return func(c *renderConfig) { c.encryptor = enc }
+}
+
+// WithSignature signs the body with cryptoutil.Signature(secret, timestamp, ".", body)
+// and sends the result in the X-Signature and X-Signature-Timestamp headers.
+//
+// Example:
+//
+//	response.Render(w, r, resp, response.WithSignature(partnerSecret))
+func WithSignature(secret string) Option {
+	return func(c *renderConfig) { c.signingSecret = secret }
+}
+
+// encryptData replaces resp.Data with its EncryptedData form.
+func encryptData(enc *cryptoutil.AESGCM, resp Response) (Response, error) {
+	if resp.Data == nil {
+		return resp, nil
+	}
+	ciphertext, err := enc.Encrypt(resp.Data)
+	if err != nil {
+		return resp, err
+	}
+	resp.Data = EncryptedData{Alg: "A256GCM", Ciphertext: ciphertext}
+	return resp, nil
+}
+
+// signBody sets the signature headers for body.
+func signBody(w http.ResponseWriter, secret string, body []byte) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	w.Header().Set(HeaderSignatureTimestamp, ts)
+	w.Header().Set(HeaderSignature, cryptoutil.Signature(secret, ts, ".", string(body)))
+}
+
+// VerifySignature checks the X-Signature of a response body on the client side.
+// The timestamp must be within tolerance of the local clock (0 disables the check).
+//
+// Example:
+//
+//	body, _ := io.ReadAll(httpResp.Body)
+//	if err := response.VerifySignature(secret, httpResp.Header, body, 5*time.Minute); err != nil {
+//	    return err
+//	}
+func VerifySignature(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	sig := header.Get(HeaderSignature)
+	ts := header.Get(HeaderSignatureTimestamp)
+	if sig == "" || ts == "" {
+		return ErrMissingSignature
+	}
+
+	expected := cryptoutil.Signature(secret, ts, ".", string(body))
+	// Constant-time comparison prevents timing attacks
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		unix, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		skew := time.Since(time.Unix(unix, 0))
+		if skew > tolerance || skew < -tolerance {
+			return ErrExpiredSignature
+		}
+	}
+	return nil
+}
+
+// DecodeEncrypted reads an envelope whose "data" was encrypted with
+// WithEncryption and returns the decrypted, typed data.
+// Non-success envelopes return an *APIError, just like Decode.
+//
+// Example:
+//
+//	user, err := response.DecodeEncrypted[User](bytes.NewReader(body), partnerAES)
+func DecodeEncrypted[T any](r io.Reader, enc *cryptoutil.AESGCM) (T, error) {
+	var out T
+
+	env, err := DecodeTyped[*EncryptedData](r)
+	if err != nil {
+		return out, err
+	}
+	if env.Data == nil {
+		return out, nil
+	}
+
+	if err := enc.Decrypt(env.Data.Ciphertext, &out); err != nil {
+		return out, fmt.Errorf("response: decrypt data: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAESKey = "12345678901234567890123456789012"
+
+func TestRender_WithEncryption(t *testing.T) {
+	enc, err := cryptoutil.NewAESGCM(testAESKey)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	ctx := activity.WithRequestID(req.Context(), "req-secure-1")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(ctx, "user found", testUser{ID: 1, Name: "Budi"}), WithEncryption(enc)))
+
+	assert.NotContains(t, rec.Body.String(), "Budi")
+	assert.Contains(t, rec.Body.String(), `"alg":"A256GCM"`)
+	assert.Contains(t, rec.Body.String(), `"request_id":"req-secure-1"`) // meta stays readable
+
+	user, err := DecodeEncrypted[testUser](rec.Body, enc)
+	require.NoError(t, err)
+	assert.Equal(t, testUser{ID: 1, Name: "Budi"}, user)
+}
+
+func TestDecodeEncrypted_Errors(t *testing.T) {
+	enc, _ := cryptoutil.NewAESGCM(testAESKey)
+	other, _ := cryptoutil.NewAESGCM("abcdefghijklmnopqrstuvwxyz123456")
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, Render(rec, nil, OK(t.Context(), "ok", testUser{ID: 1}), WithEncryption(enc)))
+
+	_, err := DecodeEncrypted[testUser](bytes.NewReader(rec.Body.Bytes()), other)
+	assert.ErrorContains(t, err, "decrypt data")
+
+	// Error envelopes are reported as *APIError, data stays empty
+	rec = httptest.NewRecorder()
+	require.NoError(t, Render(rec, nil, NotFound(t.Context(), "not found"), WithEncryption(enc)))
+	_, err = DecodeEncrypted[testUser](rec.Body, enc)
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+}
+
+func TestRender_WithSignature(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, nil, OK(t.Context(), "ok", "payload"), WithSignature("s3cret")))
+
+	ts := rec.Header().Get(HeaderSignatureTimestamp)
+	require.NotEmpty(t, ts)
+	assert.Equal(t, cryptoutil.Signature("s3cret", ts, ".", rec.Body.String()), rec.Header().Get(HeaderSignature))
+	assert.NoError(t, VerifySignature("s3cret", rec.Header(), rec.Body.Bytes(), time.Minute))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"meta":{}}`)
+	sign := func(secret string, ts time.Time) http.Header {
+		h := http.Header{}
+		unix := strconv.FormatInt(ts.Unix(), 10)
+		h.Set(HeaderSignatureTimestamp, unix)
+		h.Set(HeaderSignature, cryptoutil.Signature(secret, unix, ".", string(body)))
+		return h
+	}
+
+	assert.NoError(t, VerifySignature("k", sign("k", time.Now()), body, time.Minute))
+	assert.ErrorIs(t, VerifySignature("k", http.Header{}, body, time.Minute), ErrMissingSignature)
+	assert.ErrorIs(t, VerifySignature("k", sign("wrong", time.Now()), body, time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature("k", sign("k", time.Now()), []byte(`{"meta":{"x":1}}`), time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature("k", sign("k", time.Now().Add(-time.Hour)), body, time.Minute), ErrExpiredSignature)
+	assert.NoError(t, VerifySignature("k", sign("k", time.Now().Add(-time.Hour)), body, 0))
+}
+
+func TestRender_EncryptionKeepsPlaintextETag(t *testing.T) {
+	enc, _ := cryptoutil.NewAESGCM(testAESKey)
+	data := testUser{ID: 1}
+	tag, _ := ETag(data)
+
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req.Header.Set("If-None-Match", tag)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(req.Context(), "ok", data), WithEncryption(enc), WithETag(), WithSignature("k")))
+
+	assert.Equal(t, 304, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderSignature))
+}
@@ -0,0 +1,201 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+)
+
+// Content types used by the streaming writers.
+const (
+	ContentTypeNDJSON = "application/x-ndjson"
+	ContentTypeSSE    = "text/event-stream"
+)
+
+// StreamSummary is the data of the trailer record.
+type StreamSummary struct {
+	Count int `json:"count"` // number of items written
+}
+
+// FromSeq adapts an infallible iterator for StreamNDJSON / StreamSSE.
+func FromSeq[T any](seq iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v := range seq {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// FromChan adapts a channel for StreamNDJSON / StreamSSE.
+// The stream ends when ch is closed, or with ctx.Err() as soon as ctx is
+// cancelled, even while ch is idle.
+//
+// Example:
+//
+//	_ = response.StreamSSE(ctx, w, response.FromChan(ctx, job.Progress()))
+func FromChan[T any](ctx context.Context, ch <-chan T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				var zero T
+				yield(zero, ctx.Err())
+				return
+			case v, ok := <-ch:
+				if !ok || !yield(v, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// StreamNDJSON streams items as newline-delimited JSON (one record per line):
+//
+//	{"meta":{"success":true,"message":"stream started","status_code":200,"request_id":"..."}}
+//	{"data":{"id":1}}
+//	{"data":{"id":2}}
+//	{"meta":{"success":true,"message":"stream completed","status_code":200,"request_id":"..."},"data":{"count":2}}
+//
+// Every record is flushed immediately. If seq yields an error, ctx is cancelled
+// or an item cannot be encoded, the stream stops and the trailer meta reports
+// the failure (mapped with FromError); that error is returned.
+//
+// Example:
+//
+//	rows := repo.StreamTransactions(ctx) // iter.Seq2[Transaction, error]
+//	_ = response.StreamNDJSON(ctx, w, rows)
+func StreamNDJSON[T any](ctx context.Context, w http.ResponseWriter, seq iter.Seq2[T, error]) error {
+	return stream(ctx, w, seq, ContentTypeNDJSON, writeNDJSONRecord)
+}
+
+// StreamSSE streams items as Server-Sent Events. The leading meta uses the
+// "meta" event, every item a "data" event with an incrementing id, and the
+// trailer the "end" event:
+//
+//	event: meta
+//	data: {"meta":{"success":true,"message":"stream started",...}}
+//
+//	event: data
+//	id: 1
+//	data: {"data":{"progress":50}}
+//
+//	event: end
+//	data: {"meta":{"success":true,"message":"stream completed",...},"data":{"count":1}}
+//
+// Error handling is the same as StreamNDJSON.
+func StreamSSE[T any](ctx context.Context, w http.ResponseWriter, seq iter.Seq2[T, error]) error {
+	return stream(ctx, w, seq, ContentTypeSSE, writeSSERecord)
+}
+
+// recordKind identifies the position of a record in the stream.
+type recordKind int
+
+const (
+	recordHead recordKind = iota
+	recordItem
+	recordTrailer
+)
+
+// recordWriter writes a single encoded stream record; n is the 1-based item index.
+type recordWriter func(w io.Writer, kind recordKind, n int, record []byte) error
+
+// streamItem is the record written for every item.
+type streamItem struct {
+	Data any `json:"data"`
+}
+
+// stream is the format-independent streaming loop.
+func stream[T any](ctx context.Context, w http.ResponseWriter, seq iter.Seq2[T, error], contentType string, write recordWriter) error {
+	rc := http.NewResponseController(w)
+	head := OK(ctx, "stream started", nil)
+
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	h.Set("Cache-Control", "no-cache")
+	h.Set(HeaderRequestID, head.Meta.RequestID)
+	w.WriteHeader(http.StatusOK)
+
+	// flush sends buffered bytes now; unsupported writers are ignored
+	flush := func() { _ = rc.Flush() }
+
+	if err := writeRecord(w, write, recordHead, 0, head); err != nil {
+		return err
+	}
+	flush()
+
+	count := 0
+	var streamErr error
+	for item, err := range seq {
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			streamErr = err
+			break
+		}
+
+		// Encoding failures can still be reported in the trailer;
+		// write failures mean the client is gone
+		b, err := json.Marshal(streamItem{Data: item})
+		if err != nil {
+			streamErr = err
+			break
+		}
+		if err := write(w, recordItem, count+1, b); err != nil {
+			return err
+		}
+		count++
+		flush()
+	}
+
+	trailer := OK(ctx, "stream completed", StreamSummary{Count: count})
+	if streamErr != nil {
+		trailer = FromError(ctx, streamErr)
+		trailer.Meta.Message = "stream interrupted: " + trailer.Meta.Message
+		trailer.Data = StreamSummary{Count: count}
+	}
+	// Keep a single request ID for the whole stream
+	trailer.Meta.RequestID = head.Meta.RequestID
+
+	if err := writeRecord(w, write, recordTrailer, 0, trailer); err != nil {
+		return err
+	}
+	flush()
+	return streamErr
+}
+
+// writeRecord encodes a meta record, which is always encodable, and writes it.
+func writeRecord(w io.Writer, write recordWriter, kind recordKind, n int, record Response) error {
+	b, _ := json.Marshal(record)
+	return write(w, kind, n, b)
+}
+
+// writeNDJSONRecord writes record as a single JSON line.
+func writeNDJSONRecord(w io.Writer, _ recordKind, _ int, record []byte) error {
+	_, err := w.Write(append(record, '\n'))
+	return err
+}
+
+// writeSSERecord writes record as a Server-Sent Event.
+func writeSSERecord(w io.Writer, kind recordKind, n int, record []byte) error {
+	var event string
+	switch kind {
+	case recordHead:
+		event = "event: meta\n"
+	case recordItem:
+		event = "event: data\nid: " + strconv.Itoa(n) + "\n"
+	default:
+		event = "event: end\n"
+	}
+
+	// json.Marshal never emits raw newlines, so a single data line is enough
+	_, err := fmt.Fprintf(w, "%sdata: %s\n\n", event, record)
+	return err
+}
@@ -0,0 +1,157 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeNDJSON splits an NDJSON body into generic records.
+func decodeNDJSON(t *testing.T, body string) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		var rec map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &rec), line)
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-stream-1")
+	rec := httptest.NewRecorder()
+
+	err := StreamNDJSON(ctx, rec, FromSeq(slices.Values([]int{1, 2, 3})))
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, ContentTypeNDJSON, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "req-stream-1", rec.Header().Get(HeaderRequestID))
+	assert.True(t, rec.Flushed)
+
+	records := decodeNDJSON(t, rec.Body.String())
+	require.Len(t, records, 5)
+
+	head := records[0]["meta"].(map[string]any)
+	assert.Equal(t, "stream started", head["message"])
+	assert.Equal(t, "req-stream-1", head["request_id"])
+
+	assert.Equal(t, map[string]any{"data": float64(1)}, records[1])
+	assert.Equal(t, map[string]any{"data": float64(3)}, records[3])
+
+	trailer := records[4]["meta"].(map[string]any)
+	assert.Equal(t, true, trailer["success"])
+	assert.Equal(t, "stream completed", trailer["message"])
+	assert.Equal(t, "req-stream-1", trailer["request_id"])
+	assert.Equal(t, map[string]any{"count": float64(3)}, records[4]["data"])
+}
+
+func TestStreamNDJSON_InterruptedBySeqError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	boom := errors.New("db connection lost")
+
+	var seq iter.Seq2[int, error] = func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, boom)
+	}
+
+	err := StreamNDJSON(context.Background(), rec, seq)
+	assert.ErrorIs(t, err, boom)
+
+	records := decodeNDJSON(t, rec.Body.String())
+	require.Len(t, records, 3)
+
+	trailer := records[2]["meta"].(map[string]any)
+	assert.Equal(t, false, trailer["success"])
+	assert.Equal(t, float64(500), trailer["status_code"])
+	assert.Equal(t, "stream interrupted: internal server error", trailer["message"])
+	assert.Equal(t, map[string]any{"count": float64(1)}, records[2]["data"])
+}
+
+func TestStreamNDJSON_InterruptedByContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := httptest.NewRecorder()
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	cancel()
+
+	err := StreamNDJSON(ctx, rec, FromChan(ctx, ch))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	records := decodeNDJSON(t, rec.Body.String())
+	require.Len(t, records, 2) // head + trailer
+	assert.Equal(t, false, records[1]["meta"].(map[string]any)["success"])
+}
+
+func TestStreamNDJSON_CancelledWhileChannelIdle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := httptest.NewRecorder()
+
+	ch := make(chan int) // never closed: the producer went quiet
+	go func() {
+		ch <- 1
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- StreamNDJSON(ctx, rec, FromChan(ctx, ch)) }()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream still blocked on the idle channel after cancel")
+	}
+
+	records := decodeNDJSON(t, rec.Body.String())
+	require.Len(t, records, 3) // head + item + trailer
+	assert.Equal(t, map[string]any{"count": float64(1)}, records[2]["data"])
+	assert.Equal(t, false, records[2]["meta"].(map[string]any)["success"])
+}
+
+func TestStreamNDJSON_UnencodableItem(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := StreamNDJSON(context.Background(), rec, FromSeq(slices.Values([]any{1, make(chan int)})))
+	assert.Error(t, err)
+
+	records := decodeNDJSON(t, rec.Body.String())
+	require.Len(t, records, 3)
+	assert.Equal(t, map[string]any{"count": float64(1)}, records[2]["data"])
+}
+
+func TestStreamSSE(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-sse-1")
+	rec := httptest.NewRecorder()
+
+	err := StreamSSE(ctx, rec, FromSeq(slices.Values([]string{"a", "b"})))
+	require.NoError(t, err)
+
+	assert.Equal(t, ContentTypeSSE, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	require.Len(t, events, 4)
+	assert.True(t, strings.HasPrefix(events[0], "event: meta\ndata: {\"meta\":"))
+	assert.Equal(t, "event: data\nid: 1\ndata: {\"data\":\"a\"}", events[1])
+	assert.Equal(t, "event: data\nid: 2\ndata: {\"data\":\"b\"}", events[2])
+	assert.True(t, strings.HasPrefix(events[3], "event: end\ndata: {\"meta\":{\"success\":true"))
+	assert.Contains(t, events[3], `"request_id":"req-sse-1"`)
+}
@@ -0,0 +1,275 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single field that failed validation.
+//
+// Example JSON output:
+//
+//	{ "field": "address.city", "rule": "required", "message": "address.city is required" }
+type FieldError struct {
+	Field   string `json:"field"`           // JSON path of the field, e.g. "items[0].qty"
+	Rule    string `json:"rule"`            // validation rule (tag) that failed
+	Param   string `json:"param,omitempty"` // rule parameter, e.g. "3" for min=3
+	Message string `json:"message"`         // human-readable, lowercase
+}
+
+// ValidationFailed builds a 422 Unprocessable Entity response from the error
+// returned by validator.Struct. Each failed field is listed in "errors" using
+// its JSON path (e.g. "items[1].qty"), so frontends can highlight the exact
+// input; fields tagged `json:"-"` are not listed.
+// A *pagination.FilterError lists each rejected filter[...] parameter instead.
+//
+// JSON paths are derived from the validated struct type, which errors of
+// validator.Struct carry. With another validator instance, pass the validated
+// value as well; otherwise the paths follow the errors' Namespace().
+//
+// Errors that are not validation errors still produce a 422 without details,
+// except validator.InvalidValidationError (a programming error) which yields a 500.
+//
+// Example:
+//
+//	if err := validator.Struct(req); err != nil {
+//	    return response.ValidationFailed(ctx, err)
+//	}
+//	if err := myValidate.Struct(req); err != nil {
+//	    return response.ValidationFailed(ctx, err, req)
+//	}
+func ValidationFailed(ctx context.Context, err error, validated ...any) Response {
+	var invalid *validator.InvalidValidationError
+	if errors.As(err, &invalid) {
+		return InternalError(ctx)
+	}
+
+	resp := UnprocessableEntity(ctx, "validation failed")
+
+	var root reflect.Type
+	if len(validated) > 0 {
+		root = reflect.TypeOf(validated[0])
+	}
+
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		resp.Errors = make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			if e, ok := newFieldError(fe, root); ok {
+				resp.Errors = append(resp.Errors, e)
+			}
+		}
+	}
+
+	// Invalid list filters are reported per query parameter
+	var filterErr *pagination.FilterError
+	if errors.As(err, &filterErr) {
+		resp.Errors = make([]FieldError, 0, len(filterErr.Issues))
+		for _, issue := range filterErr.Issues {
+			resp.Errors = append(resp.Errors, FieldError{
+				Field:   issue.Field,
+				Rule:    issue.Rule,
+				Param:   issue.Param,
+				Message: issue.Message,
+			})
+		}
+	}
+
+	return resp
+}
+
+// crossFieldTags are the rules whose parameter names a sibling field.
+var crossFieldTags = map[string]bool{
+	"eqfield": true, "nefield": true,
+	"gtfield": true, "gtefield": true,
+	"ltfield": true, "ltefield": true,
+}
+
+// newFieldError converts a go-playground field error into a FieldError.
+// root is the validated type, or nil to use the one carried by validator.Struct
+// errors. It reports false for fields hidden from JSON.
+func newFieldError(fe validator.FieldError, root reflect.Type) (FieldError, bool) {
+	if root == nil {
+		if r, ok := fe.(interface{ RootType() reflect.Type }); ok {
+			root = r.RootType()
+		}
+	}
+
+	field, param := fieldPath(fe.Namespace()), fe.Param()
+	if root != nil {
+		if path, parent, ok := jsonPath(root, fe.StructNamespace()); ok {
+			if path == "" {
+				return FieldError{}, false
+			}
+			field = path
+			if crossFieldTags[fe.Tag()] {
+				param, _ = jsonFieldName(parent, param)
+			}
+		}
+	}
+
+	return FieldError{
+		Field:   field,
+		Rule:    fe.Tag(),
+		Param:   param,
+		Message: fieldMessage(field, fe.Tag(), param),
+	}, true
+}
+
+// fieldPath strips the top-level struct name from a validator namespace.
+// "CreateUserRequest.Address.City" → "Address.City"
+func fieldPath(namespace string) string {
+	if _, rest, ok := strings.Cut(namespace, "."); ok {
+		return rest
+	}
+	return namespace
+}
+
+// jsonPath maps a Go struct namespace ("CreateUser.Items[1].Qty") of root to
+// its JSON path ("items[1].qty"), flattening embedded structs like
+// encoding/json. It also returns the struct type holding the field.
+// The path is "" when the field is hidden with `json:"-"`; ok is false when
+// the namespace does not belong to root.
+func jsonPath(root reflect.Type, structNamespace string) (path string, parent reflect.Type, ok bool) {
+	segments := strings.Split(structNamespace, ".")
+	t := indirectType(root)
+	if len(segments) < 2 || segments[0] != t.Name() {
+		return "", nil, false
+	}
+
+	parts := make([]string, 0, len(segments)-1)
+	for _, seg := range segments[1:] {
+		// "Items[1]" → field "Items", index suffix "[1]"
+		name, suffix := seg, ""
+		if i := strings.IndexByte(seg, '['); i >= 0 {
+			name, suffix = seg[:i], seg[i:]
+		}
+
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return "", nil, false
+		}
+		sf, found := t.FieldByName(name)
+		if !found {
+			return "", nil, false
+		}
+		parent = t
+
+		jsonName, hidden := jsonFieldName(t, name)
+		switch {
+		case hidden:
+			return "", parent, true
+		case sf.Anonymous && !hasJSONName(sf) && indirectType(sf.Type).Kind() == reflect.Struct:
+			// Embedded struct: its fields are promoted, no path segment
+		default:
+			parts = append(parts, jsonName+suffix)
+		}
+
+		t = sf.Type
+		for range strings.Count(suffix, "[") {
+			t = indirectType(t)
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				return "", nil, false
+			}
+		}
+	}
+	return strings.Join(parts, "."), parent, true
+}
+
+// jsonFieldName returns the JSON name of the field called goName in struct t,
+// falling back to goName. hidden reports a `json:"-"` field, whose name must
+// not be shown to clients ("" is returned).
+func jsonFieldName(t reflect.Type, goName string) (name string, hidden bool) {
+	if t == nil {
+		return goName, false
+	}
+	sf, ok := t.FieldByName(goName)
+	if !ok {
+		return goName, false
+	}
+	tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	switch tag {
+	case "-":
+		return "", true
+	case "":
+		return goName, false
+	}
+	return tag, false
+}
+
+// hasJSONName reports whether sf names itself in its json tag.
+func hasJSONName(sf reflect.StructField) bool {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	return name != "" && name != "-"
+}
+
+// indirectType dereferences pointer types.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldMessage returns a lowercase, human-readable message for common rules.
+func fieldMessage(field, tag, param string) string {
+	switch tag {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email address"
+	case "url", "uri", "http_url":
+		return field + " must be a valid url"
+	case "uuid", "uuid4", "uuid7":
+		return field + " must be a valid uuid"
+	case "numeric", "number":
+		return field + " must be numeric"
+	case "alpha":
+		return field + " must contain letters only"
+	case "alphanum":
+		return field + " must contain letters and numbers only"
+	case "boolean":
+		return field + " must be a boolean"
+	case "datetime":
+		return fmt.Sprintf("%s must match the format %s", field, param)
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", field, param)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s", field, param)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, param)
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, param)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", field, param)
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", field, param)
+	case "eq":
+		return fmt.Sprintf("%s must be equal to %s", field, param)
+	case "ne":
+		return fmt.Sprintf("%s must not be equal to %s", field, param)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, strings.ReplaceAll(param, " ", ", "))
+	case "eqfield":
+		if param == "" {
+			return field + " must match another field"
+		}
+		return fmt.Sprintf("%s must match %s", field, param)
+	default:
+		if param != "" {
+			return fmt.Sprintf("%s failed on the %s=%s rule", field, tag, param)
+		}
+		return fmt.Sprintf("%s failed on the %s rule", field, tag)
+	}
+}
@@ -0,0 +1,152 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/validator"
+	playground "github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type testItem struct {
+	Qty int `json:"qty" validate:"min=1"`
+}
+
+type testCreateUser struct {
+	Name    string      `json:"name" validate:"required"`
+	Email   string      `json:"email" validate:"required,email"`
+	Role    string      `json:"role" validate:"oneof=admin user"`
+	Address testAddress `json:"address"`
+	Items   []testItem  `json:"items" validate:"dive"`
+}
+
+func TestValidationFailed(t *testing.T) {
+	req := testCreateUser{
+		Name:  "Budi",
+		Email: "not-an-email",
+		Role:  "root",
+		Items: []testItem{{Qty: 1}, {Qty: 0}},
+	}
+	err := validator.Struct(req)
+	require.Error(t, err)
+
+	resp := ValidationFailed(context.Background(), err)
+
+	assert.Equal(t, 422, resp.Meta.StatusCode)
+	assert.False(t, resp.Meta.Success)
+	assert.Equal(t, "validation failed", resp.Meta.Message)
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+		{Field: "role", Rule: "oneof", Param: "admin user", Message: "role must be one of [admin, user]"},
+		{Field: "address.city", Rule: "required", Message: "address.city is required"},
+		{Field: "items[1].qty", Rule: "min", Param: "1", Message: "items[1].qty must be at least 1"},
+	}, resp.Errors)
+}
+
+type testSignupAudit struct {
+	Source string `json:"source" validate:"required"`
+}
+
+type testSignup struct {
+	testSignupAudit
+	Password string                 `json:"password" validate:"required,min=8"`
+	Confirm  string                 `json:"password_confirmation" validate:"eqfield=Password"`
+	Internal string                 `json:"-" validate:"required"`
+	NoTag    string                 `validate:"required"`
+	Referrer *testAddress           `json:"referrer,omitempty"`
+	Phones   map[string]testItem    `json:"phones" validate:"dive"`
+	Nested   []map[string]*testItem `json:"nested" validate:"dive,dive"`
+}
+
+func TestValidationFailed_JSONPaths(t *testing.T) {
+	req := &testSignup{
+		Password: "hunter2-secret",
+		Confirm:  "other",
+		Referrer: &testAddress{},
+		Phones:   map[string]testItem{"home": {Qty: 0}},
+		Nested:   []map[string]*testItem{{"a": {Qty: 0}}},
+	}
+	err := validator.Struct(req)
+	require.Error(t, err)
+
+	resp := ValidationFailed(context.Background(), err)
+
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "source", Rule: "required", Message: "source is required"},
+		{Field: "password_confirmation", Rule: "eqfield", Param: "password", Message: "password_confirmation must match password"},
+		{Field: "NoTag", Rule: "required", Message: "NoTag is required"},
+		{Field: "referrer.city", Rule: "required", Message: "referrer.city is required"},
+		{Field: "phones[home].qty", Rule: "min", Param: "1", Message: "phones[home].qty must be at least 1"},
+		{Field: "nested[0][a].qty", Rule: "min", Param: "1", Message: "nested[0][a].qty must be at least 1"},
+	}, resp.Errors)
+	assert.NotContains(t, toJSON(t, resp), "Internal")
+}
+
+func TestValidationFailed_OwnValidator(t *testing.T) {
+	req := testCreateUser{Email: "budi@example.com", Role: "user", Items: []testItem{{Qty: 0}}}
+	err := playground.New().Struct(req)
+	require.Error(t, err)
+
+	// Without the value, paths follow the validator's Namespace()
+	assert.ElementsMatch(t, []string{"Name", "Address.City", "Items[0].Qty"}, errorFields(ValidationFailed(context.Background(), err)))
+	assert.ElementsMatch(t, []string{"name", "address.city", "items[0].qty"}, errorFields(ValidationFailed(context.Background(), err, req)))
+}
+
+func TestValidationFailed_HiddenSibling(t *testing.T) {
+	type pin struct {
+		Secret string `json:"-"`
+		Repeat string `json:"repeat" validate:"eqfield=Secret"`
+	}
+	err := validator.Struct(pin{Secret: "1234", Repeat: "0000"})
+
+	resp := ValidationFailed(context.Background(), err)
+
+	assert.Equal(t, []FieldError{
+		{Field: "repeat", Rule: "eqfield", Message: "repeat must match another field"},
+	}, resp.Errors)
+}
+
+func errorFields(resp Response) []string {
+	fields := make([]string, len(resp.Errors))
+	for i, e := range resp.Errors {
+		fields[i] = e.Field
+	}
+	return fields
+}
+
+func TestValidationFailed_JSON(t *testing.T) {
+	err := validator.Struct(testCreateUser{Email: "budi@example.com", Role: "user", Address: testAddress{City: "Jakarta"}})
+	require.Error(t, err)
+
+	data, _ := json.Marshal(ValidationFailed(context.Background(), err))
+
+	assert.Contains(t, string(data), `"errors":[{"field":"name","rule":"required","message":"name is required"}]`)
+}
+
+func TestValidationFailed_NonValidationErrors(t *testing.T) {
+	ctx := context.Background()
+
+	resp := ValidationFailed(ctx, errors.New("boom"))
+	assert.Equal(t, 422, resp.Meta.StatusCode)
+	assert.Empty(t, resp.Errors)
+
+	// Passing a non-struct is a programming error
+	resp = ValidationFailed(ctx, validator.Struct("not a struct"))
+	assert.Equal(t, 500, resp.Meta.StatusCode)
+}
+
+func TestValidationFailed_Problem(t *testing.T) {
+	err := validator.Struct(testCreateUser{Email: "budi@example.com", Role: "user", Address: testAddress{City: "Jakarta"}})
+
+	p := ValidationFailed(context.Background(), err).Problem()
+
+	assert.Len(t, p.Extensions["errors"], 1)
+}
@@ -0,0 +1,266 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
+)
+
+// Header names and content types used when writing responses.
+const (
+	HeaderRequestID = "X-Request-ID"                    // echoes Meta.RequestID
+	ContentTypeJSON = "application/json; charset=utf-8" // default envelope encoding
+)
+
+// Write encodes the response as JSON and writes it to w.
+// It sets Content-Type, the X-Request-ID header from Meta.RequestID and the
+// status code from Meta.StatusCode. A 204 response is written without a body.
+//
+// If the envelope cannot be encoded, a 500 envelope carrying the same request ID
+// is written instead and the encoding error is returned.
+//
+// Example:
+//
+//	_ = response.OK(ctx, "user found", user).Write(w)
+func (r Response) Write(w http.ResponseWriter) error {
+	return Render(w, nil, r)
+}
+
+// Option customizes a single Render call.
+type Option func(*renderConfig)
+
+// renderConfig holds the options of a single Render call.
+type renderConfig struct {
+	baseURL      string    // public URL used to build Link headers
+	etag         bool      // compute a strong ETag over data
+	lastModified time.Time // Last-Modified of the resource
+	fields       bool      // honor ?fields= sparse fieldsets
+	strictFields bool      // reject unknown fields with 400
+
+	encryptor     *cryptoutil.AESGCM // encrypts data when set
+	signingSecret string             // signs the body when set
+}
+
+// WithBaseURL sets the public URL (scheme, host, path and query) used to build
+// pagination Link headers. Use it behind proxies, where the request does not
+// carry the public scheme or host.
+//
+// Example:
+//
+//	response.Render(w, r, resp, response.WithBaseURL("https://api.example.com/v1/users?status=active"))
+func WithBaseURL(baseURL string) Option {
+	return func(c *renderConfig) { c.baseURL = baseURL }
+}
+
+// Render writes resp to w exactly like Response.Write, but also takes the
+// incoming request into account: HEAD requests receive headers only,
+// error responses are rendered as problem details when the client asks for
+// application/problem+json (or ModeProblem is set), paginated responses
+// carry an RFC 8288 Link header, conditional GETs are answered with
+// 304 Not Modified when WithETag / WithLastModified is used, "data" is
+// trimmed to ?fields= when WithFields is used, and "data" is encrypted and
+// the body signed when WithEncryption / WithSignature is used. The encoding
+// is negotiated from the Accept header (JSON by default, see RegisterEncoder);
+// 406 Not Acceptable is sent when nothing matches. Optional meta fields
+// (API version, latency, deprecation) are mirrored as headers. req may be nil.
+//
+// Example:
+//
+//	func GetUser(w http.ResponseWriter, r *http.Request) {
+//	    _ = response.Render(w, r, response.OK(r.Context(), "user found", user))
+//	}
+func Render(w http.ResponseWriter, req *http.Request, resp Response, opts ...Option) error {
+	var cfg renderConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Normalize the status code the same way JSONMarshal does
+	resp.Meta.StatusCode = resp.statusCode()
+
+	// Never leave the client with a half-written or empty body:
+	// on failure, fall back to a 500 envelope that keeps the original request ID.
+	fail := func(err error) error {
+		writeBody(w, req, internalErrorFor(resp.Meta.RequestID))
+		return err
+	}
+
+	enc, ok := negotiate(req)
+	if !ok {
+		// Nothing the client accepts: answer 406 in the default encoding
+		notAcceptable := NotAcceptable(req.Context(), "none of the accepted media types is supported")
+		notAcceptable.Meta.RequestID = resp.Meta.RequestID
+		resp, enc = notAcceptable, defaultEncoder()
+	}
+
+	var err error
+	if cfg.fields {
+		if resp, err = applyFields(req, resp, cfg.strictFields); err != nil {
+			return fail(err)
+		}
+	}
+
+	// Caching validators describe the plaintext representation
+	plain := resp
+	if cfg.encryptor != nil {
+		if resp, err = encryptData(cfg.encryptor, resp); err != nil {
+			return fail(err)
+		}
+	}
+
+	body, contentType, err := encode(req, resp, enc)
+	if err != nil {
+		return fail(err)
+	}
+
+	notModified, err := applyCaching(w, req, plain, cfg, enc.mediaType)
+	if err != nil {
+		return fail(err)
+	}
+
+	writeHeaders(w, resp.Meta.RequestID, contentType)
+	if req != nil {
+		addVary(w.Header(), "Accept")
+	}
+	if link := linkHeader(req, resp, cfg.baseURL); link != "" {
+		w.Header().Set("Link", link)
+	}
+	writeMetaHeaders(w.Header(), resp.Meta)
+	if notModified {
+		writeStatusAndBody(w, req, http.StatusNotModified, nil)
+		return nil
+	}
+	if cfg.signingSecret != "" {
+		signBody(w, cfg.signingSecret, body)
+	}
+	writeStatusAndBody(w, req, resp.Meta.StatusCode, body)
+	return nil
+}
+
+// linkHeader builds the RFC 8288 Link header for a paginated response
+// (Paginated or CursorPaginated). It returns "" when resp is not paginated
+// or no URL is known.
+func linkHeader(req *http.Request, resp Response, baseURL string) string {
+	if resp.Pagination == nil && resp.Cursor == nil {
+		return ""
+	}
+	if baseURL == "" {
+		baseURL = requestURL(req)
+	}
+	if baseURL == "" {
+		return ""
+	}
+
+	var links map[string]string
+	var err error
+	if resp.Pagination != nil {
+		links, err = resp.Pagination.Links(baseURL)
+	} else {
+		links, err = resp.Cursor.Links(baseURL)
+	}
+	if err != nil {
+		return ""
+	}
+
+	// Keep a stable order: prev, then next
+	var parts []string
+	for _, rel := range []string{"prev", "next"} {
+		if l, ok := links[rel]; ok {
+			parts = append(parts, l)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// requestURL reconstructs the absolute URL of req, or "" when req is nil.
+func requestURL(req *http.Request) string {
+	if req == nil || req.URL == nil {
+		return ""
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + req.Host + req.URL.RequestURI()
+}
+
+// encode marshals resp with enc and returns the body together with its
+// content type. Errors are rendered as problem details when the JSON
+// encoder is used and req asks for them (see wantsProblem).
+func encode(req *http.Request, resp Response, enc encoder) ([]byte, string, error) {
+	if enc.mediaType == defaultEncoder().mediaType && wantsProblem(req, resp) {
+		p := resp.Problem()
+		if req != nil && req.URL != nil {
+			p.Instance = req.URL.Path
+		}
+		body, err := json.Marshal(p)
+		return body, ContentTypeProblem, err
+	}
+
+	body, err := enc.encode(resp)
+	return body, enc.contentType, err
+}
+
+// statusCode returns Meta.StatusCode, defaulting to 400 when unset.
+func (r Response) statusCode() int {
+	if r.Meta.StatusCode == 0 {
+		return http.StatusBadRequest
+	}
+	return r.Meta.StatusCode
+}
+
+// internalErrorFor builds a 500 envelope for an already known request ID.
+func internalErrorFor(requestID string) Response {
+	return Response{Meta: Meta{
+		Success:    false,
+		Message:    "internal server error",
+		StatusCode: http.StatusInternalServerError,
+		RequestID:  requestID,
+	}}
+}
+
+// writeBody encodes a response that is known to be JSON-encodable and writes it
+// with the default encoder.
+func writeBody(w http.ResponseWriter, req *http.Request, resp Response) {
+	body, contentType, _ := encode(req, resp, defaultEncoder())
+	writeHeaders(w, resp.Meta.RequestID, contentType)
+	writeStatusAndBody(w, req, resp.Meta.StatusCode, body)
+}
+
+// writeHeaders sets the headers shared by every envelope.
+func writeHeaders(w http.ResponseWriter, requestID, contentType string) {
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	if requestID != "" {
+		h.Set(HeaderRequestID, requestID)
+	}
+}
+
+// writeStatusAndBody writes the status line and, when allowed, the body.
+func writeStatusAndBody(w http.ResponseWriter, req *http.Request, status int, body []byte) {
+	if !bodyAllowed(status) {
+		// 204 and 304 must not carry a body or a content type
+		w.Header().Del("Content-Type")
+		w.WriteHeader(status)
+		return
+	}
+
+	w.WriteHeader(status)
+	// HEAD requests get the same headers as GET, but no body
+	if req != nil && req.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// bodyAllowed reports whether a response with the given status may include a body.
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
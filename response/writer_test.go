@@ -0,0 +1,123 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponse_Write(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-write-1")
+	rec := httptest.NewRecorder()
+
+	err := Created(ctx, "user created", map[string]string{"name": "Budi"}).Write(rec)
+	require.NoError(t, err)
+
+	assert.Equal(t, 201, rec.Code)
+	assert.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "req-write-1", rec.Header().Get(HeaderRequestID))
+
+	var got Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.True(t, got.Meta.Success)
+	assert.Equal(t, "user created", got.Meta.Message)
+	assert.Equal(t, "req-write-1", got.Meta.RequestID)
+}
+
+func TestResponse_WriteNoContent(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, NoContent(context.Background()).Write(rec))
+
+	assert.Equal(t, 204, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rec.Header().Get(HeaderRequestID))
+}
+
+func TestResponse_WriteDefaultsStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Response{}.Write(rec))
+
+	assert.Equal(t, 400, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status_code":400`)
+}
+
+func TestResponse_WriteEncodingFailure(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-broken")
+	rec := httptest.NewRecorder()
+
+	// Channels cannot be encoded as JSON
+	err := OK(ctx, "ok", make(chan int)).Write(rec)
+	assert.Error(t, err)
+
+	assert.Equal(t, 500, rec.Code)
+	assert.Equal(t, "req-broken", rec.Header().Get(HeaderRequestID))
+
+	var got Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.False(t, got.Meta.Success)
+	assert.Equal(t, "internal server error", got.Meta.Message)
+	assert.Equal(t, "req-broken", got.Meta.RequestID)
+}
+
+func TestRender_HeadRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodHead, "/users/1", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, Render(rec, req, OK(req.Context(), "user found", "data")))
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestRender_PaginatedLinkHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://api.example.com/v1/users?status=active&page=2", nil)
+	rec := httptest.NewRecorder()
+
+	resp := Paginated(req.Context(), "users retrieved", []int{1}, pagination.New("2", "10", 35))
+	require.NoError(t, Render(rec, req, resp))
+
+	assert.Equal(t, `<http://api.example.com/v1/users?limit=10&page=1&status=active>; rel="prev", `+
+		`<http://api.example.com/v1/users?limit=10&page=3&status=active>; rel="next"`, rec.Header().Get("Link"))
+}
+
+func TestRender_PaginatedWithBaseURL(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://10.0.0.5:8080/users", nil)
+	rec := httptest.NewRecorder()
+
+	resp := Paginated(req.Context(), "users retrieved", []int{1}, pagination.New("1", "10", 35))
+	require.NoError(t, Render(rec, req, resp, WithBaseURL("https://api.example.com/v1/users")))
+
+	assert.Equal(t, `<https://api.example.com/v1/users?limit=10&page=2>; rel="next"`, rec.Header().Get("Link"))
+}
+
+func TestRender_CursorPaginatedLinkHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://api.example.com/v1/users?status=active&cursor=old&limit=10", nil)
+	rec := httptest.NewRecorder()
+
+	c := pagination.Cursor{Limit: 10, HasMore: true, HasPrev: true, NextCursor: "n1", PrevCursor: "p1"}
+	require.NoError(t, Render(rec, req, CursorPaginated(req.Context(), "users retrieved", []int{1}, c)))
+
+	assert.Equal(t, `<http://api.example.com/v1/users?cursor=p1&limit=10&status=active>; rel="prev", `+
+		`<http://api.example.com/v1/users?cursor=n1&limit=10&status=active>; rel="next"`, rec.Header().Get("Link"))
+}
+
+func TestRender_PaginatedWithoutRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	resp := Paginated(context.Background(), "users retrieved", []int{1}, pagination.New("1", "10", 35))
+	require.NoError(t, resp.Write(rec))
+
+	assert.Empty(t, rec.Header().Get("Link"))
+	assert.Contains(t, rec.Body.String(), `"pagination"`)
+}
@@ -5,6 +5,7 @@
 package validator
 
 import (
+	"reflect"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
@@ -34,7 +35,29 @@ func Get() *validator.Validate {
 //
 //	err := validator.Struct(req)
 func Struct(s any) error {
-	return Get().Struct(s)
+	err := Get().Struct(s)
+
+	// Remember the validated type so JSON paths can be derived from the
+	// Go namespaces (see response.ValidationFailed)
+	if verrs, ok := err.(validator.ValidationErrors); ok {
+		root := reflect.TypeOf(s)
+		for i, fe := range verrs {
+			verrs[i] = rootedFieldError{FieldError: fe, root: root}
+		}
+	}
+	return err
+}
+
+// rootedFieldError is a validator.FieldError that also carries the type of
+// the validated struct. Every FieldError method behaves as before.
+type rootedFieldError struct {
+	validator.FieldError
+	root reflect.Type
+}
+
+// RootType returns the type passed to Struct.
+func (e rootedFieldError) RootType() reflect.Type {
+	return e.root
 }
 
 // Var validates a single variable.
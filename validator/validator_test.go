@@ -1,8 +1,10 @@
 package validator
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,3 +43,30 @@ func TestValidator(t *testing.T) {
 		assert.Equal(t, v1, v2)
 	})
 }
+
+type Address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type Customer struct {
+	FullName string  `json:"full_name" validate:"required"`
+	Address  Address `json:"address"`
+	Internal string  `json:"-" validate:"required"`
+}
+
+func TestStruct_KeepsGoNames(t *testing.T) {
+	err := Struct(&Customer{})
+	assert.Error(t, err)
+
+	var fields []string
+	for _, fe := range err.(validator.ValidationErrors) {
+		fields = append(fields, fe.Namespace())
+		// The validated type travels with each error
+		root, ok := fe.(interface{ RootType() reflect.Type })
+		if assert.True(t, ok) {
+			assert.Equal(t, reflect.TypeOf(&Customer{}), root.RootType())
+		}
+	}
+	assert.ElementsMatch(t, []string{"Customer.FullName", "Customer.Address.City", "Customer.Internal"}, fields)
+	assert.Contains(t, err.Error(), "Key: 'Customer.FullName' Error:Field validation for 'FullName' failed on the 'required' tag")
+}